@@ -0,0 +1 @@
+data "woodpecker_agents" "agents" {}
@@ -0,0 +1,4 @@
+data "woodpecker_organization_secrets" "docker" {
+  owner = "example_org"
+  image = "plugins/docker"
+}
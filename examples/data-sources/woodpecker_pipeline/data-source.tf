@@ -0,0 +1,17 @@
+data "woodpecker_pipeline" "main" {
+  repo_owner = "example_user"
+  repo_name  = "woodpecker_test"
+  branch     = "main"
+}
+
+# only roll out when the latest pipeline on main is green
+resource "terraform_data" "rollout" {
+  input = data.woodpecker_pipeline.main.commit
+
+  lifecycle {
+    precondition {
+      condition     = data.woodpecker_pipeline.main.status == "success"
+      error_message = "The latest pipeline on main did not succeed."
+    }
+  }
+}
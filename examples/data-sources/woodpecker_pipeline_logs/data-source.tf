@@ -0,0 +1,15 @@
+data "woodpecker_pipeline" "latest" {
+  repo_owner = "example_user"
+  repo_name  = "woodpecker_test"
+}
+
+data "woodpecker_pipeline_logs" "build" {
+  repo_owner = data.woodpecker_pipeline.latest.repo_owner
+  repo_name  = data.woodpecker_pipeline.latest.repo_name
+  number     = data.woodpecker_pipeline.latest.number
+  step       = "build"
+}
+
+output "build_log" {
+  value = join("\n", data.woodpecker_pipeline_logs.build.lines)
+}
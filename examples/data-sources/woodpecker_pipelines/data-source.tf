@@ -0,0 +1,7 @@
+data "woodpecker_pipelines" "failed" {
+  repo_owner = "example_user"
+  repo_name  = "woodpecker_test"
+  branch     = "main"
+  status     = "failure"
+  event      = "push"
+}
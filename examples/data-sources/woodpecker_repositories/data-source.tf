@@ -0,0 +1,12 @@
+data "woodpecker_repositories" "org" {
+  owner      = "example_org"
+  name_regex = "^service-"
+}
+
+# activate every matching repository
+resource "woodpecker_repository" "org" {
+  for_each = { for repo in data.woodpecker_repositories.org.repositories : repo.full_name => repo }
+
+  owner = each.value.owner
+  name  = each.value.name
+}
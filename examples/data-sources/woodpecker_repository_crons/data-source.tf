@@ -0,0 +1,4 @@
+data "woodpecker_repository_crons" "crons" {
+  repo_owner = "example_user"
+  repo_name  = "woodpecker_test"
+}
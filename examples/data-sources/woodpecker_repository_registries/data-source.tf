@@ -0,0 +1,4 @@
+data "woodpecker_repository_registries" "registries" {
+  repo_owner = "example_user"
+  repo_name  = "woodpecker_test"
+}
@@ -0,0 +1,13 @@
+data "woodpecker_repository_secrets" "pull_request" {
+  repo_owner = "example_user"
+  repo_name  = "woodpecker_test"
+  event      = "pull_request"
+}
+
+# fail the plan when a secret is exposed to pull requests
+check "no_pull_request_secrets" {
+  assert {
+    condition     = length(data.woodpecker_repository_secrets.pull_request.secrets) == 0
+    error_message = "Secrets exposed to pull requests: ${join(", ", data.woodpecker_repository_secrets.pull_request.secrets[*].name)}"
+  }
+}
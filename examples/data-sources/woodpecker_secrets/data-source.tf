@@ -0,0 +1,3 @@
+data "woodpecker_secrets" "push" {
+  event = "push"
+}
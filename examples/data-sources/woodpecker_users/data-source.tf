@@ -0,0 +1,16 @@
+data "woodpecker_users" "admins" {
+  admin_only  = true
+  active_only = true
+}
+
+variable "expected_admins" {
+  type = set(string)
+}
+
+# fail the plan when someone outside of the expected group is an admin
+check "no_unexpected_admins" {
+  assert {
+    condition     = length(setsubtract(data.woodpecker_users.admins.users[*].login, var.expected_admins)) == 0
+    error_message = "Unexpected admins: ${join(", ", setsubtract(data.woodpecker_users.admins.users[*].login, var.expected_admins))}"
+  }
+}
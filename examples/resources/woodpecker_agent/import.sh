@@ -0,0 +1,2 @@
+# Syntax: <id>
+terraform import woodpecker_agent.agent 1
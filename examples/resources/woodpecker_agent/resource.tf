@@ -0,0 +1,4 @@
+resource "woodpecker_agent" "agent" {
+  name     = "runner-01"
+  capacity = 2
+}
@@ -0,0 +1,24 @@
+resource "woodpecker_repository" "repo" {
+  owner = "example_user"
+  name  = "woodpecker_test"
+}
+
+# deploy the last successful pipeline on main to staging
+resource "woodpecker_deployment" "staging" {
+  repo_owner  = woodpecker_repository.repo.owner
+  repo_name   = woodpecker_repository.repo.name
+  environment = "staging"
+  branch      = "main"
+}
+
+# promote the same pipeline to production
+resource "woodpecker_deployment" "production" {
+  repo_owner  = woodpecker_repository.repo.owner
+  repo_name   = woodpecker_repository.repo.name
+  environment = "production"
+  pipeline    = woodpecker_deployment.staging.pipeline
+
+  params = {
+    CHANGE_TICKET = "OPS-1234"
+  }
+}
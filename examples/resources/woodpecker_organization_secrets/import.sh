@@ -0,0 +1,2 @@
+# Syntax: <owner>
+terraform import woodpecker_organization_secrets.secrets "example_org"
@@ -0,0 +1,10 @@
+resource "woodpecker_organization_secrets" "secrets" {
+  owner = "example_org"
+
+  secrets = {
+    registry_token = {
+      value  = "example value"
+      events = ["push", "tag"]
+    }
+  }
+}
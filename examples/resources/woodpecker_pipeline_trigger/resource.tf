@@ -0,0 +1,21 @@
+resource "woodpecker_repository" "repo" {
+  owner  = "example_user"
+  name   = "woodpecker_test"
+  config = ".woodpecker/validate.yml"
+}
+
+resource "woodpecker_pipeline_trigger" "validate" {
+  repo_owner = woodpecker_repository.repo.owner
+  repo_name  = woodpecker_repository.repo.name
+  branch     = "main"
+  wait       = true
+
+  variables = {
+    VALIDATE_ONLY = "true"
+  }
+
+  # start a new pipeline whenever the config path changes
+  triggers = {
+    config = woodpecker_repository.repo.config
+  }
+}
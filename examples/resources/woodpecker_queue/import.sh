@@ -0,0 +1,2 @@
+# Syntax: queue
+terraform import woodpecker_queue.queue queue
@@ -0,0 +1,3 @@
+resource "woodpecker_queue" "queue" {
+  paused = true
+}
@@ -2,3 +2,12 @@ resource "woodpecker_repository" "repo" {
   owner = "example_user"
   name  = "woodpecker_test"
 }
+
+# Take over a repository activated by someone who left, and rebuild its
+# webhooks by bumping repair_trigger
+resource "woodpecker_repository" "handed_over" {
+  owner          = "example_org"
+  name           = "legacy_service"
+  owner_login    = "example_user"
+  repair_trigger = "2023-07-01"
+}
@@ -9,3 +9,11 @@ resource "woodpecker_repository_cron" "cron" {
   name       = "terraform cron"
   schedule   = "@weekly"
 }
+
+resource "woodpecker_repository_cron" "nightly" {
+  repo_owner = woodpecker_repository.repo.owner
+  repo_name  = woodpecker_repository.repo.name
+  name       = "nightly release build"
+  schedule   = "0 30 2 * * *"
+  branch     = "release"
+}
@@ -0,0 +1,2 @@
+# Syntax: <repo_owner>/<repo_name>
+terraform import woodpecker_repository_secrets.secrets "example_owner/repository"
@@ -0,0 +1,22 @@
+resource "woodpecker_repository" "repo" {
+  owner = "example_user"
+  name  = "woodpecker_test"
+}
+
+resource "woodpecker_repository_secrets" "secrets" {
+  repo_owner = woodpecker_repository.repo.owner
+  repo_name  = woodpecker_repository.repo.name
+
+  secrets = {
+    deploy_key = {
+      value  = "example value"
+      events = ["push", "tag", "deployment"]
+    }
+    docker_password = {
+      value        = "example password"
+      events       = ["push"]
+      images       = ["woodpeckerci/plugin-docker-buildx"]
+      plugins_only = true
+    }
+  }
+}
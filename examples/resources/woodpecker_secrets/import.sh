@@ -0,0 +1,2 @@
+# There is only one set of global secrets, any ID can be used
+terraform import woodpecker_secrets.secrets secrets
@@ -0,0 +1,8 @@
+resource "woodpecker_secrets" "secrets" {
+  secrets = {
+    slack_webhook = {
+      value  = "https://hooks.slack.com/services/example"
+      events = ["push", "tag", "deployment", "cron", "manual"]
+    }
+  }
+}
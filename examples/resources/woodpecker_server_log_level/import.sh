@@ -0,0 +1,2 @@
+# Syntax: log_level
+terraform import woodpecker_server_log_level.level log_level
@@ -0,0 +1,3 @@
+resource "woodpecker_server_log_level" "level" {
+  log_level = "debug"
+}
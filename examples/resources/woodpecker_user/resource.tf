@@ -1,3 +1,8 @@
 resource "woodpecker_user" "user" {
   login = "testuser"
+}
+
+resource "woodpecker_user" "admin" {
+  login = "testadmin"
+  admin = true
 }
\ No newline at end of file
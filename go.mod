@@ -6,6 +6,7 @@ require (
 	github.com/hashicorp/terraform-plugin-framework v1.2.0
 	github.com/hashicorp/terraform-plugin-go v0.15.0
 	github.com/hashicorp/terraform-plugin-testing v1.2.0
+	github.com/robfig/cron v1.2.0
 	github.com/woodpecker-ci/woodpecker v0.15.1-0.20230531192757-f91ee5d23a75
 	golang.org/x/oauth2 v0.8.0
 )
@@ -0,0 +1,168 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+// feature describes functionality only available from a given Woodpecker
+// release onwards.
+type feature struct {
+	description string
+	minVersion  serverVersion
+}
+
+var (
+	// Repositories can be activated without first refreshing the list
+	// of repositories known to Woodpecker.
+	featureActivateWithoutSync = feature{"Activating repositories without a repository sync", serverVersion{1, 0, 0, true}}
+
+	// Repositories and organizations are addressed by ID rather than
+	// owner/name, see idClient.
+	featureIDBasedAPI = feature{"The ID based API", serverVersion{1, 0, 0, true}}
+
+	featureAgents        = feature{"Agent management", serverVersion{1, 0, 0, true}}
+	featureCrons         = feature{"Repository crons", serverVersion{1, 0, 0, true}}
+	featureGlobalSecrets = feature{"Global secrets", serverVersion{1, 0, 0, true}}
+)
+
+// serverVersion is the release of Woodpecker the provider is talking to.
+// Development builds (e.g. next-f91ee5d23a) have no comparable version.
+type serverVersion struct {
+	major, minor, patch int
+	known               bool
+}
+
+func parseServerVersion(raw string) serverVersion {
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), "v")
+
+	// drop pre-release and build metadata (e.g. 1.0.0-rc.1, 1.0.0+abc)
+	if i := strings.IndexAny(raw, "-+"); i >= 0 {
+		raw = raw[:i]
+	}
+
+	parts := strings.Split(raw, ".")
+
+	if len(parts) != 3 {
+		return serverVersion{}
+	}
+
+	var numbers [3]int
+
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+
+		if err != nil {
+			return serverVersion{}
+		}
+
+		numbers[i] = number
+	}
+
+	return serverVersion{numbers[0], numbers[1], numbers[2], true}
+}
+
+func (v serverVersion) String() string {
+	if !v.known {
+		return "unknown"
+	}
+
+	return fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+}
+
+func (v serverVersion) atLeast(other serverVersion) bool {
+	if v.major != other.major {
+		return v.major > other.major
+	}
+
+	if v.minor != other.minor {
+		return v.minor > other.minor
+	}
+
+	return v.patch >= other.patch
+}
+
+// serverCapabilities describes what the Woodpecker server supports.
+type serverCapabilities struct {
+	// version as reported by the server, e.g. 1.0.2 or next-f91ee5d23a
+	rawVersion string
+	version    serverVersion
+}
+
+// has reports whether the server is known to support f.
+func (c serverCapabilities) has(f feature) bool {
+	return c.version.known && c.version.atLeast(f.minVersion)
+}
+
+// require adds an error diagnostic when the server is known to be too
+// old to support f. Servers with an unknown version are given the
+// benefit of the doubt.
+func (c serverCapabilities) require(f feature, diags *diag.Diagnostics) {
+	if !c.version.known || c.version.atLeast(f.minVersion) {
+		return
+	}
+
+	diags.AddError(
+		"Unsupported Woodpecker version",
+		fmt.Sprintf("%s requires Woodpecker %s or newer, but the server reports version %s.",
+			f.description, f.minVersion, c.rawVersion),
+	)
+}
+
+// fetchServerCapabilities queries the server's /version endpoint.
+func fetchServerCapabilities(client *http.Client, server string) (serverCapabilities, error) {
+	var capabilities serverCapabilities
+
+	resp, err := client.Get(strings.TrimSuffix(server, "/") + "/version")
+
+	if err != nil {
+		return capabilities, err
+	}
+
+	defer resp.Body.Close()
+
+	var version struct {
+		Version string `json:"version"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		return capabilities, fmt.Errorf("could not decode version: %w", err)
+	}
+
+	capabilities.rawVersion = version.Version
+	capabilities.version = parseServerVersion(version.Version)
+
+	return capabilities, nil
+}
+
+// probeIDBasedAPI reports whether the server serves the ID based API, for
+// servers without a comparable version (e.g. development builds). Unknown
+// repositories are missing from either API, but only the ID based API can
+// look up the organization of the authenticated user.
+func probeIDBasedAPI(client *http.Client, server string) (bool, error) {
+	api := newAPIClient(client, server)
+	self := new(woodpecker.User)
+
+	if err := api.do(http.MethodGet, "/api/user", nil, self); err != nil {
+		return false, err
+	}
+
+	var org struct {
+		ID int64 `json:"id"`
+	}
+
+	err := api.do(http.MethodGet, "/api/orgs/lookup/"+url.PathEscape(self.Login), nil, &org)
+
+	if isNotFound(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestParseServerVersion(t *testing.T) {
+	tests := map[string]serverVersion{
+		"0.15.9":          {0, 15, 9, true},
+		"v1.0.2":          {1, 0, 2, true},
+		"2.0.0-rc.1":      {2, 0, 0, true},
+		"1.0.0+abcdef":    {1, 0, 0, true},
+		"next-f91ee5d23a": {},
+		"":                {},
+	}
+
+	for raw, expected := range tests {
+		if version := parseServerVersion(raw); version != expected {
+			t.Errorf("%q: expected %+v, got %+v", raw, expected, version)
+		}
+	}
+}
+
+func TestServerCapabilities(t *testing.T) {
+	old := serverCapabilities{rawVersion: "0.15.9", version: parseServerVersion("0.15.9")}
+	current := serverCapabilities{rawVersion: "1.0.0", version: parseServerVersion("1.0.0")}
+	dev := serverCapabilities{rawVersion: "next-f91ee5d23a", version: parseServerVersion("next-f91ee5d23a")}
+
+	if old.has(featureCrons) || !current.has(featureCrons) || dev.has(featureCrons) {
+		t.Error("unexpected result from has")
+	}
+
+	var diags diag.Diagnostics
+
+	current.require(featureCrons, &diags)
+	dev.require(featureCrons, &diags)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	old.require(featureCrons, &diags)
+
+	if !diags.HasError() {
+		t.Fatal("expected error for outdated server")
+	}
+}
+
+func TestFetchServerCapabilities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" {
+			http.NotFound(w, r)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"source":"https://github.com/woodpecker-ci/woodpecker","version":"1.0.2"}`))
+	}))
+	defer server.Close()
+
+	capabilities, err := fetchServerCapabilities(server.Client(), server.URL+"/")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if capabilities.rawVersion != "1.0.2" || !capabilities.has(featureCrons) {
+		t.Errorf("unexpected capabilities: %+v", capabilities)
+	}
+}
+
+func TestProbeIDBasedAPI(t *testing.T) {
+	for _, legacyAPI := range []bool{false, true} {
+		fake := newFakeWoodpecker(t)
+		fake.legacyAPI = legacyAPI
+
+		idBased, err := probeIDBasedAPI(fake.httpClient(t), fake.server.URL)
+		if err != nil || idBased == legacyAPI {
+			t.Errorf("legacy API %t: unexpected result: %t, %v", legacyAPI, idBased, err)
+		}
+	}
+
+	fake := newFakeWoodpecker(t)
+	fake.fail(http.MethodGet, `^/api/user$`, http.StatusUnauthorized, 1)
+
+	if _, err := probeIDBasedAPI(fake.httpClient(t), fake.server.URL); err == nil {
+		t.Error("expected an error when the probe fails")
+	}
+}
+
+func TestAccProvider_apiDetectionFails(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			fake := testAccFake(t)
+			fake.fail(http.MethodGet, `^/version$`, http.StatusNotFound, -1)
+		},
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			// the provider doesn't guess which API to use
+			{
+				Config: `
+data "woodpecker_self" "self" {}
+`,
+				ExpectError: regexp.MustCompile("Set api_style"),
+			},
+			{
+				Config: `
+provider "woodpecker" {
+	api_style = "id"
+}
+
+data "woodpecker_self" "self" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.woodpecker_self.self", "login", "test_user"),
+				),
+			},
+		},
+	})
+}
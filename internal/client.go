@@ -0,0 +1,706 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+// idClient adapts the owner/name based woodpecker-go client to Woodpecker
+// 1.0+, which addresses repositories and organizations by numeric ID.
+// Resources keep working with owner/name; IDs are resolved on demand and
+// cached for the lifetime of the provider.
+//
+// Endpoints that were not renamed (users, global secrets, ...) fall
+// through to the embedded client.
+type idClient struct {
+	woodpecker.Client
+	apiClient
+
+	mu      sync.Mutex
+	repoIDs map[string]int64
+	orgIDs  map[string]int64
+}
+
+func newIDClient(client woodpecker.Client, httpClient *http.Client, server string) *idClient {
+	return &idClient{
+		Client:    client,
+		apiClient: newAPIClient(httpClient, server),
+		repoIDs:   map[string]int64{},
+		orgIDs:    map[string]int64{},
+	}
+}
+
+// apiClient sends the requests woodpecker-go has no method for.
+type apiClient struct {
+	http   *http.Client
+	server string
+}
+
+func newAPIClient(httpClient *http.Client, server string) apiClient {
+	return apiClient{http: httpClient, server: strings.TrimSuffix(server, "/")}
+}
+
+// do sends in (when not nil) as JSON and decodes the response into out
+// (when not nil).
+func (c apiClient) do(method, path string, in, out interface{}) error {
+	var body bytes.Buffer
+
+	if in != nil {
+		if err := json.NewEncoder(&body).Encode(in); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.server+path, &body)
+
+	if err != nil {
+		return err
+	}
+
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (c *idClient) lookupRepo(owner, name string) (*woodpecker.Repo, error) {
+	repo := new(woodpecker.Repo)
+	path := fmt.Sprintf("/api/repos/lookup/%s/%s", url.PathEscape(owner), url.PathEscape(name))
+
+	if err := c.do(http.MethodGet, path, nil, repo); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.repoIDs[owner+"/"+name] = repo.ID
+	c.mu.Unlock()
+
+	return repo, nil
+}
+
+// repoPath returns the ID based API path of the repository owner/name.
+func (c *idClient) repoPath(owner, name string) (string, error) {
+	c.mu.Lock()
+	id, ok := c.repoIDs[owner+"/"+name]
+	c.mu.Unlock()
+
+	if !ok {
+		repo, err := c.lookupRepo(owner, name)
+
+		if err != nil {
+			return "", err
+		}
+
+		id = repo.ID
+	}
+
+	return "/api/repos/" + strconv.FormatInt(id, 10), nil
+}
+
+// orgPath returns the ID based API path of the organization (or user)
+// owner.
+func (c *idClient) orgPath(owner string) (string, error) {
+	c.mu.Lock()
+	id, ok := c.orgIDs[owner]
+	c.mu.Unlock()
+
+	if !ok {
+		var org struct {
+			ID int64 `json:"id"`
+		}
+
+		if err := c.do(http.MethodGet, "/api/orgs/lookup/"+url.PathEscape(owner), nil, &org); err != nil {
+			return "", err
+		}
+
+		c.mu.Lock()
+		c.orgIDs[owner] = org.ID
+		c.mu.Unlock()
+
+		id = org.ID
+	}
+
+	return "/api/orgs/" + strconv.FormatInt(id, 10), nil
+}
+
+func (c *idClient) forgetRepo(owner, name string) {
+	c.mu.Lock()
+	delete(c.repoIDs, owner+"/"+name)
+	c.mu.Unlock()
+}
+
+// repos
+
+func (c *idClient) Repo(owner, name string) (*woodpecker.Repo, error) {
+	// the lookup endpoint returns the full repository
+	return c.lookupRepo(owner, name)
+}
+
+func (c *idClient) RepoPost(owner, name string) (*woodpecker.Repo, error) {
+	// repositories are activated by their ID within the forge, which is
+	// only listed alongside the repositories available to the user
+	var repos []struct {
+		woodpecker.Repo
+		ForgeRemoteID string `json:"forge_remote_id"`
+	}
+
+	if err := c.do(http.MethodGet, "/api/user/repos?all=true", nil, &repos); err != nil {
+		return nil, err
+	}
+
+	for _, repo := range repos {
+		if repo.Owner != owner || repo.Name != name {
+			continue
+		}
+
+		activated := new(woodpecker.Repo)
+		path := "/api/repos?forge_remote_id=" + url.QueryEscape(repo.ForgeRemoteID)
+
+		if err := c.do(http.MethodPost, path, nil, activated); err != nil {
+			return nil, err
+		}
+
+		return activated, nil
+	}
+
+	return nil, fmt.Errorf("repository %s/%s was not found in the forge", owner, name)
+}
+
+func (c *idClient) RepoPatch(owner, name string, patch *woodpecker.RepoPatch) (*woodpecker.Repo, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	repo := new(woodpecker.Repo)
+
+	return repo, c.do(http.MethodPatch, path, patch, repo)
+}
+
+func (c *idClient) RepoRemove(owner, name string) error {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return err
+	}
+
+	if err := c.do(http.MethodDelete, path+"?remove=true", nil, nil); err != nil {
+		return err
+	}
+
+	c.forgetRepo(owner, name)
+
+	return nil
+}
+
+func (c *idClient) RepoMove(owner, name, newFullName string) error {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return err
+	}
+
+	if err := c.do(http.MethodPost, path+"/move?to="+url.QueryEscape(newFullName), nil, nil); err != nil {
+		return err
+	}
+
+	c.forgetRepo(owner, name)
+
+	return nil
+}
+
+func (c *idClient) RepoChown(owner, name string) (*woodpecker.Repo, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	repo := new(woodpecker.Repo)
+
+	return repo, c.do(http.MethodPost, path+"/chown", nil, repo)
+}
+
+func (c *idClient) RepoRepair(owner, name string) error {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return err
+	}
+
+	return c.do(http.MethodPost, path+"/repair", nil, nil)
+}
+
+func (c *idClient) RepoDel(owner, name string) error {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return err
+	}
+
+	if err := c.do(http.MethodDelete, path, nil, nil); err != nil {
+		return err
+	}
+
+	c.forgetRepo(owner, name)
+
+	return nil
+}
+
+// crons
+
+func (c *idClient) CronList(owner, name string) ([]*woodpecker.Cron, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var crons []*woodpecker.Cron
+
+	return crons, c.do(http.MethodGet, path+"/cron", nil, &crons)
+}
+
+func (c *idClient) CronGet(owner, name string, cronID int64) (*woodpecker.Cron, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cron := new(woodpecker.Cron)
+
+	return cron, c.do(http.MethodGet, fmt.Sprintf("%s/cron/%d", path, cronID), nil, cron)
+}
+
+func (c *idClient) CronCreate(owner, name string, in *woodpecker.Cron) (*woodpecker.Cron, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cron := new(woodpecker.Cron)
+
+	return cron, c.do(http.MethodPost, path+"/cron", in, cron)
+}
+
+func (c *idClient) CronUpdate(owner, name string, in *woodpecker.Cron) (*woodpecker.Cron, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cron := new(woodpecker.Cron)
+
+	return cron, c.do(http.MethodPatch, fmt.Sprintf("%s/cron/%d", path, in.ID), in, cron)
+}
+
+func (c *idClient) CronDelete(owner, name string, cronID int64) error {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return err
+	}
+
+	return c.do(http.MethodDelete, fmt.Sprintf("%s/cron/%d", path, cronID), nil, nil)
+}
+
+// repository secrets
+
+func (c *idClient) Secret(owner, name, secretName string) (*woodpecker.Secret, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	secret := new(woodpecker.Secret)
+
+	return secret, c.do(http.MethodGet, path+"/secrets/"+url.PathEscape(secretName), nil, secret)
+}
+
+func (c *idClient) SecretList(owner, name string) ([]*woodpecker.Secret, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var secrets []*woodpecker.Secret
+
+	return secrets, c.do(http.MethodGet, path+"/secrets", nil, &secrets)
+}
+
+func (c *idClient) SecretCreate(owner, name string, in *woodpecker.Secret) (*woodpecker.Secret, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	secret := new(woodpecker.Secret)
+
+	return secret, c.do(http.MethodPost, path+"/secrets", in, secret)
+}
+
+func (c *idClient) SecretUpdate(owner, name string, in *woodpecker.Secret) (*woodpecker.Secret, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	secret := new(woodpecker.Secret)
+
+	return secret, c.do(http.MethodPatch, path+"/secrets/"+url.PathEscape(in.Name), in, secret)
+}
+
+func (c *idClient) SecretDelete(owner, name, secretName string) error {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return err
+	}
+
+	return c.do(http.MethodDelete, path+"/secrets/"+url.PathEscape(secretName), nil, nil)
+}
+
+// registries
+
+func (c *idClient) Registry(owner, name, hostname string) (*woodpecker.Registry, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	registry := new(woodpecker.Registry)
+
+	return registry, c.do(http.MethodGet, path+"/registry/"+url.PathEscape(hostname), nil, registry)
+}
+
+func (c *idClient) RegistryList(owner, name string) ([]*woodpecker.Registry, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var registries []*woodpecker.Registry
+
+	return registries, c.do(http.MethodGet, path+"/registry", nil, &registries)
+}
+
+func (c *idClient) RegistryCreate(owner, name string, in *woodpecker.Registry) (*woodpecker.Registry, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	registry := new(woodpecker.Registry)
+
+	return registry, c.do(http.MethodPost, path+"/registry", in, registry)
+}
+
+func (c *idClient) RegistryUpdate(owner, name string, in *woodpecker.Registry) (*woodpecker.Registry, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	registry := new(woodpecker.Registry)
+
+	return registry, c.do(http.MethodPatch, path+"/registry/"+url.PathEscape(in.Address), in, registry)
+}
+
+func (c *idClient) RegistryDelete(owner, name, hostname string) error {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return err
+	}
+
+	return c.do(http.MethodDelete, path+"/registry/"+url.PathEscape(hostname), nil, nil)
+}
+
+// organization secrets
+
+func (c *idClient) OrgSecret(owner, secretName string) (*woodpecker.Secret, error) {
+	path, err := c.orgPath(owner)
+
+	if err != nil {
+		return nil, err
+	}
+
+	secret := new(woodpecker.Secret)
+
+	return secret, c.do(http.MethodGet, path+"/secrets/"+url.PathEscape(secretName), nil, secret)
+}
+
+func (c *idClient) OrgSecretList(owner string) ([]*woodpecker.Secret, error) {
+	path, err := c.orgPath(owner)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var secrets []*woodpecker.Secret
+
+	return secrets, c.do(http.MethodGet, path+"/secrets", nil, &secrets)
+}
+
+func (c *idClient) OrgSecretCreate(owner string, in *woodpecker.Secret) (*woodpecker.Secret, error) {
+	path, err := c.orgPath(owner)
+
+	if err != nil {
+		return nil, err
+	}
+
+	secret := new(woodpecker.Secret)
+
+	return secret, c.do(http.MethodPost, path+"/secrets", in, secret)
+}
+
+func (c *idClient) OrgSecretUpdate(owner string, in *woodpecker.Secret) (*woodpecker.Secret, error) {
+	path, err := c.orgPath(owner)
+
+	if err != nil {
+		return nil, err
+	}
+
+	secret := new(woodpecker.Secret)
+
+	return secret, c.do(http.MethodPatch, path+"/secrets/"+url.PathEscape(in.Name), in, secret)
+}
+
+func (c *idClient) OrgSecretDelete(owner, secretName string) error {
+	path, err := c.orgPath(owner)
+
+	if err != nil {
+		return err
+	}
+
+	return c.do(http.MethodDelete, path+"/secrets/"+url.PathEscape(secretName), nil, nil)
+}
+
+// pipelines
+
+// getPipeline fetches a pipeline including its workflows, which Woodpecker
+// 1.0 lists as "workflows" rather than "steps".
+func (c *idClient) getPipeline(path string) (*woodpecker.Pipeline, error) {
+	var pipeline struct {
+		woodpecker.Pipeline
+		Workflows []*woodpecker.Step `json:"workflows"`
+	}
+
+	if err := c.do(http.MethodGet, path, nil, &pipeline); err != nil {
+		return nil, err
+	}
+
+	if len(pipeline.Workflows) > 0 {
+		pipeline.Steps = pipeline.Workflows
+	}
+
+	return &pipeline.Pipeline, nil
+}
+
+func (c *idClient) Pipeline(owner, name string, number int) (*woodpecker.Pipeline, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getPipeline(fmt.Sprintf("%s/pipelines/%d", path, number))
+}
+
+func (c *idClient) PipelineLast(owner, name, branch string) (*woodpecker.Pipeline, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	path += "/pipelines/latest"
+
+	if branch != "" {
+		path += "?branch=" + url.QueryEscape(branch)
+	}
+
+	return c.getPipeline(path)
+}
+
+func (c *idClient) PipelineCreate(owner, name string, options *woodpecker.PipelineOptions) (*woodpecker.Pipeline, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := new(woodpecker.Pipeline)
+
+	return pipeline, c.do(http.MethodPost, path+"/pipelines", options, pipeline)
+}
+
+func (c *idClient) PipelineList(owner, name string) ([]*woodpecker.Pipeline, error) {
+	return c.PipelineListPage(owner, name, 1)
+}
+
+func (c *idClient) PipelineListPage(owner, name string, page int) ([]*woodpecker.Pipeline, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var pipelines []*woodpecker.Pipeline
+
+	return pipelines, c.do(http.MethodGet, fmt.Sprintf("%s/pipelines?page=%d", path, page), nil, &pipelines)
+}
+
+func (c *idClient) Deploy(owner, name string, number int, env string, params map[string]string) (*woodpecker.Pipeline, error) {
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+
+	for key, value := range params {
+		query.Set(key, value)
+	}
+
+	query.Set("event", "deployment")
+	query.Set("deploy_to", env)
+
+	pipeline := new(woodpecker.Pipeline)
+
+	return pipeline, c.do(http.MethodPost, fmt.Sprintf("%s/pipelines/%d?%s", path, number, query.Encode()), nil, pipeline)
+}
+
+// PipelineLogs returns the log of the step with the process ID pid. Logs
+// are fetched by step ID since Woodpecker 1.0, and returned line by line.
+func (c *idClient) PipelineLogs(owner, name string, number, pid int) ([]*woodpecker.Logs, error) {
+	pipeline, err := c.Pipeline(owner, name, number)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var stepID int64
+
+	for _, workflow := range pipeline.Steps {
+		for _, step := range workflow.Children {
+			if step.PID == pid {
+				stepID = step.ID
+			}
+		}
+	}
+
+	if stepID == 0 {
+		return nil, fmt.Errorf("pipeline #%d has no step with pid %d", number, pid)
+	}
+
+	path, err := c.repoPath(owner, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []struct {
+		Data []byte `json:"data"`
+	}
+
+	if err := c.do(http.MethodGet, fmt.Sprintf("%s/logs/%d/%d", path, number, stepID), nil, &entries); err != nil {
+		return nil, err
+	}
+
+	logs := make([]*woodpecker.Logs, len(entries))
+
+	for i, entry := range entries {
+		logs[i] = &woodpecker.Logs{Output: string(entry.Data)}
+	}
+
+	return logs, nil
+}
+
+// legacyClient adds the calls woodpecker-go lacks to the owner/name based
+// API of Woodpecker before 1.0.
+type legacyClient struct {
+	woodpecker.Client
+	apiClient
+}
+
+func newLegacyClient(client woodpecker.Client, httpClient *http.Client, server string) *legacyClient {
+	return &legacyClient{
+		Client:    client,
+		apiClient: newAPIClient(httpClient, server),
+	}
+}
+
+func (c *legacyClient) RepoRemove(owner, name string) error {
+	path := fmt.Sprintf("/api/repos/%s/%s?remove=true", url.PathEscape(owner), url.PathEscape(name))
+
+	return c.do(http.MethodDelete, path, nil, nil)
+}
+
+func (c *legacyClient) PipelineListPage(owner, name string, page int) ([]*woodpecker.Pipeline, error) {
+	path := fmt.Sprintf("/api/repos/%s/%s/pipelines?page=%d", url.PathEscape(owner), url.PathEscape(name), page)
+
+	var pipelines []*woodpecker.Pipeline
+
+	return pipelines, c.do(http.MethodGet, path, nil, &pipelines)
+}
+
+// repoRemover deletes repositories along with their pipelines, secrets,
+// and crons. woodpecker-go's RepoDel only deactivates them.
+type repoRemover interface {
+	RepoRemove(owner, name string) error
+}
+
+// pipelinePager lists pipelines page by page. woodpecker-go's PipelineList
+// only returns the first page.
+type pipelinePager interface {
+	PipelineListPage(owner, name string, page int) ([]*woodpecker.Pipeline, error)
+}
+
+// listPipelinePage returns a page of the pipelines of owner/name, newest
+// first. Clients that can't page only have a first page.
+func listPipelinePage(client woodpecker.Client, owner, name string, page int) ([]*woodpecker.Pipeline, error) {
+	if pager, ok := client.(pipelinePager); ok {
+		return pager.PipelineListPage(owner, name, page)
+	}
+
+	if page > 1 {
+		return nil, nil
+	}
+
+	return client.PipelineList(owner, name)
+}
@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func TestIDClient(t *testing.T) {
+	var lookups int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/repos/lookup/owner/name", func(w http.ResponseWriter, r *http.Request) {
+		lookups++
+		_ = json.NewEncoder(w).Encode(woodpecker.Repo{ID: 42, Owner: "owner", Name: "name", FullName: "owner/name"})
+	})
+	mux.HandleFunc("/api/repos/42/secrets/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(woodpecker.Secret{ID: 1, Name: "token"})
+	})
+	mux.HandleFunc("/api/repos/42/cron", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+
+		var cron woodpecker.Cron
+		_ = json.NewDecoder(r.Body).Decode(&cron)
+		cron.ID = 7
+		_ = json.NewEncoder(w).Encode(cron)
+	})
+	mux.HandleFunc("/api/orgs/lookup/owner", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":3,"name":"owner","is_user":false}`))
+	})
+	mux.HandleFunc("/api/orgs/3/secrets/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(woodpecker.Secret{ID: 2, Name: "token"})
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := newIDClient(nil, &http.Client{Transport: &errorTransport{base: http.DefaultTransport}}, server.URL+"/")
+
+	repo, err := client.Repo("owner", "name")
+	if err != nil || repo.ID != 42 {
+		t.Fatalf("unexpected repo lookup result: %+v, %v", repo, err)
+	}
+
+	secret, err := client.Secret("owner", "name", "token")
+	if err != nil || secret.ID != 1 {
+		t.Fatalf("unexpected secret: %+v, %v", secret, err)
+	}
+
+	cron, err := client.CronCreate("owner", "name", &woodpecker.Cron{Name: "nightly", Schedule: "@daily"})
+	if err != nil || cron.ID != 7 || cron.Name != "nightly" {
+		t.Fatalf("unexpected cron: %+v, %v", cron, err)
+	}
+
+	if lookups != 1 {
+		t.Errorf("expected repository ID to be cached, got %d lookups", lookups)
+	}
+
+	orgSecret, err := client.OrgSecret("owner", "token")
+	if err != nil || orgSecret.ID != 2 {
+		t.Fatalf("unexpected organization secret: %+v, %v", orgSecret, err)
+	}
+
+	_, err = client.Registry("other", "repo", "docker.io")
+	if !isNotFound(err) {
+		t.Errorf("expected not found error for unknown repository, got: %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func NewDataSourceAgents() datasource.DataSource {
+	return &DataSourceAgents{}
+}
+
+type DataSourceAgents struct {
+	client       woodpecker.Client
+	capabilities serverCapabilities
+}
+
+func (d *DataSourceAgents) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agents"
+}
+
+func (r DataSourceAgents) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to list the agents registered with Woodpecker. Requires admin privileges.",
+
+		Attributes: map[string]schema.Attribute{
+
+			// Computed Attributes
+			"agents": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Registered agents",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Agent ID",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Agent name",
+						},
+						"owner_id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "ID of the user that created the agent",
+						},
+						"created": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Time the agent was created (Unix timestamp)",
+						},
+						"updated": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Time the agent was last updated (Unix timestamp)",
+						},
+						"last_contact": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Time the agent last contacted the server (Unix timestamp)",
+						},
+						"platform": schema.StringAttribute{
+							Computed:    true,
+							Description: "Platform reported by the agent (e.g. linux/amd64)",
+						},
+						"backend": schema.StringAttribute{
+							Computed:    true,
+							Description: "Backend reported by the agent (e.g. docker)",
+						},
+						"capacity": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of workflows the agent can run in parallel",
+						},
+						"version": schema.StringAttribute{
+							Computed:    true,
+							Description: "Version reported by the agent",
+						},
+						"no_schedule": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether new pipelines are not scheduled on the agent",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *DataSourceAgents) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*woodpeckerProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *woodpeckerProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+	r.capabilities = p.capabilities
+}
+
+func (r DataSourceAgents) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errProviderUnconfigured.Error())
+		return
+	}
+
+	r.capabilities.require(featureAgents, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	wAgents, err := r.client.AgentList()
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Could not list agents", err)
+		return
+	}
+
+	resourceData := Agents{Agents: make([]AgentData, len(wAgents))}
+
+	for i, wAgent := range wAgents {
+		WoodpeckerToAgentData(*wAgent, &resourceData.Agents[i])
+	}
+
+	diags := resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
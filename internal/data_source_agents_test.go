@@ -0,0 +1,35 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataAgents(t *testing.T) {
+	name := "data.woodpecker_agents.all"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: agentsDataConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "agents.#", "1"),
+					resource.TestCheckResourceAttr(name, "agents.0.name", "test_agent"),
+				),
+			},
+		},
+	})
+}
+
+const agentsDataConfig = `
+resource "woodpecker_agent" "test_agent" {
+	name = "test_agent"
+}
+
+data "woodpecker_agents" "all" {
+	depends_on = [woodpecker_agent.test_agent]
+}
+`
@@ -33,8 +33,16 @@ func (r DataSourceOrganizationSecret) Schema(ctx context.Context, req datasource
 				Required:    true,
 				Description: "Organization name",
 			},
+
+			// Optional Attributes (exactly one of id or name)
+			"id": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Secret ID",
+			},
 			"name": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Description: "Secret Name",
 			},
 
@@ -53,14 +61,16 @@ func (r DataSourceOrganizationSecret) Schema(ctx context.Context, req datasource
 				Computed:    true,
 				Description: "One or more event types where secret is available (push, tag, pull_request, deployment, cron, manual)",
 			},
-			"id": schema.Int64Attribute{
-				Computed:    true,
-				Description: "",
-			},
 		},
 	}
 }
 
+func (r DataSourceOrganizationSecret) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		ValidateExactlyOneOf{attributes: []string{"id", "name"}},
+	}
+}
+
 func (r *DataSourceOrganizationSecret) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -82,6 +92,11 @@ func (r *DataSourceOrganizationSecret) Configure(_ context.Context, req datasour
 }
 
 func (r DataSourceOrganizationSecret) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errProviderUnconfigured.Error())
+		return
+	}
+
 	// unmarshall request config into resourceData
 	var resourceData OrganizationSecretData
 	diags := req.Config.Get(ctx, &resourceData)
@@ -94,7 +109,20 @@ func (r DataSourceOrganizationSecret) Read(ctx context.Context, req datasource.R
 	owner := resourceData.Owner.ValueString()
 	secretName := resourceData.Name.ValueString()
 
-	secret, err := r.client.OrgSecret(owner, secretName)
+	var secret *woodpecker.Secret
+	var err error
+
+	if !resourceData.Name.IsNull() {
+		secret, err = r.client.OrgSecret(owner, secretName)
+	} else {
+		var secrets []*woodpecker.Secret
+		secrets, err = r.client.OrgSecretList(owner)
+		secret = findSecretByID(secrets, resourceData.ID.ValueInt64())
+
+		if err == nil && secret == nil {
+			err = fmt.Errorf("no secret with ID %d found", resourceData.ID.ValueInt64())
+		}
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError("Error retrieving organization secret", err.Error())
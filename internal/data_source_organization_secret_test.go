@@ -21,6 +21,7 @@ func TestAccDataOrgSecret(t *testing.T) {
 					resource.TestCheckNoResourceAttr(name, "value"),
 					resource.TestCheckResourceAttr(name, "events.#", "1"),
 					resource.TestCheckResourceAttr(name, "events.0", "push"),
+					resource.TestCheckResourceAttrPair("data.woodpecker_organization_secret.by_id", "name", name, "name"),
 				),
 			},
 		},
@@ -40,4 +41,9 @@ data "woodpecker_organization_secret" "test_org_secret" {
 	name = woodpecker_organization_secret.test_org_secret.name
 	depends_on = [woodpecker_organization_secret.test_org_secret]
 }
+
+data "woodpecker_organization_secret" "by_id" {
+	owner = woodpecker_organization_secret.test_org_secret.owner
+	id    = woodpecker_organization_secret.test_org_secret.id
+}
 `
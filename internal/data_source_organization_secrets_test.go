@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataOrgSecrets(t *testing.T) {
+	name := "data.woodpecker_organization_secrets.test_org_secrets"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: orgSecretsDataConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "owner", "test_org"),
+					resource.TestCheckResourceAttr(name, "secrets.#", "1"),
+					resource.TestCheckResourceAttr(name, "secrets.0.name", "test_org_secret"),
+					resource.TestCheckResourceAttrPair(name, "secrets.0.id", "woodpecker_organization_secret.test_org_secret", "id"),
+					resource.TestCheckResourceAttr("data.woodpecker_organization_secrets.pull_request", "secrets.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const orgSecretsDataConfig = `
+resource "woodpecker_organization_secret" "test_org_secret" {
+	owner  = "test_org"
+	name   = "test_org_secret"
+	value  = "test_value"
+	events = ["push"]
+}
+
+data "woodpecker_organization_secrets" "test_org_secrets" {
+	owner      = woodpecker_organization_secret.test_org_secret.owner
+	depends_on = [woodpecker_organization_secret.test_org_secret]
+}
+
+data "woodpecker_organization_secrets" "pull_request" {
+	owner      = woodpecker_organization_secret.test_org_secret.owner
+	event      = "pull_request"
+	depends_on = [woodpecker_organization_secret.test_org_secret]
+}
+`
@@ -0,0 +1,307 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func NewDataSourcePipeline() datasource.DataSource {
+	return &DataSourcePipeline{}
+}
+
+type DataSourcePipeline struct {
+	client woodpecker.Client
+}
+
+func (d *DataSourcePipeline) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline"
+}
+
+func (r DataSourcePipeline) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := pipelineAttributes()
+
+	// Required Attributes
+	attributes["repo_owner"] = schema.StringAttribute{
+		Required:    true,
+		Description: "User or organization responsible for repository",
+	}
+	attributes["repo_name"] = schema.StringAttribute{
+		Required:    true,
+		Description: "Repository name",
+	}
+
+	// Optional Attributes (at most one of number or branch)
+	attributes["number"] = schema.Int64Attribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Pipeline number. When not set, the latest pipeline is looked up.",
+	}
+	attributes["branch"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Branch to look up the latest pipeline of, defaults to the repository's default branch",
+	}
+
+	// Computed Attributes
+	attributes["workflows"] = schema.ListNestedAttribute{
+		Computed:    true,
+		Description: "Workflows of the pipeline",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.Int64Attribute{
+					Computed:    true,
+					Description: "Workflow ID",
+				},
+				"pid": schema.Int64Attribute{
+					Computed:    true,
+					Description: "Workflow process ID within the pipeline",
+				},
+				"name": schema.StringAttribute{
+					Computed:    true,
+					Description: "Workflow name",
+				},
+				"state": schema.StringAttribute{
+					Computed:    true,
+					Description: "Workflow state (e.g. pending, running, success, failure)",
+				},
+				"error": schema.StringAttribute{
+					Computed:    true,
+					Description: "Error the workflow failed with",
+				},
+				"started": schema.Int64Attribute{
+					Computed:    true,
+					Description: "Time the workflow started (Unix timestamp)",
+				},
+				"stopped": schema.Int64Attribute{
+					Computed:    true,
+					Description: "Time the workflow stopped (Unix timestamp)",
+				},
+				"platform": schema.StringAttribute{
+					Computed:    true,
+					Description: "Platform the workflow ran on (e.g. linux/amd64)",
+				},
+				"steps": schema.ListNestedAttribute{
+					Computed:    true,
+					Description: "Steps of the workflow",
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"id": schema.Int64Attribute{
+								Computed:    true,
+								Description: "Step ID",
+							},
+							"pid": schema.Int64Attribute{
+								Computed:    true,
+								Description: "Step process ID within the pipeline",
+							},
+							"name": schema.StringAttribute{
+								Computed:    true,
+								Description: "Step name",
+							},
+							"state": schema.StringAttribute{
+								Computed:    true,
+								Description: "Step state (e.g. pending, running, success, failure)",
+							},
+							"error": schema.StringAttribute{
+								Computed:    true,
+								Description: "Error the step failed with",
+							},
+							"exit_code": schema.Int64Attribute{
+								Computed:    true,
+								Description: "Exit code of the step",
+							},
+							"started": schema.Int64Attribute{
+								Computed:    true,
+								Description: "Time the step started (Unix timestamp)",
+							},
+							"stopped": schema.Int64Attribute{
+								Computed:    true,
+								Description: "Time the step stopped (Unix timestamp)",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get information on a pipeline, " +
+			"or the latest pipeline on a branch",
+		Attributes: attributes,
+	}
+}
+
+func (r DataSourcePipeline) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		ValidateAtMostOneOf{attributes: []string{"number", "branch"}},
+	}
+}
+
+func (r *DataSourcePipeline) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*woodpeckerProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *woodpeckerProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r DataSourcePipeline) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errProviderUnconfigured.Error())
+		return
+	}
+
+	// unmarshall request config into resourceData
+	var resourceData Pipeline
+	diags := req.Config.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repoOwner := resourceData.RepoOwner.ValueString()
+	repoName := resourceData.RepoName.ValueString()
+
+	var pipeline *woodpecker.Pipeline
+	var err error
+
+	if !resourceData.Number.IsNull() {
+		pipeline, err = r.client.Pipeline(repoOwner, repoName, int(resourceData.Number.ValueInt64()))
+	} else {
+		pipeline, err = r.lastPipeline(repoOwner, repoName, resourceData.Branch.ValueString())
+	}
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error retrieving pipeline", err)
+		return
+	}
+
+	WoodpeckerToPipeline(*pipeline, &resourceData)
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+// lastPipeline returns the latest pipeline on branch, or on the
+// repository's default branch when branch is empty. The legacy API
+// doesn't default an empty branch itself.
+func (r DataSourcePipeline) lastPipeline(repoOwner, repoName, branch string) (*woodpecker.Pipeline, error) {
+	if branch == "" {
+		repo, err := r.client.Repo(repoOwner, repoName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		branch = repo.Branch
+	}
+
+	return r.client.PipelineLast(repoOwner, repoName, branch)
+}
+
+// pipelineAttributes returns the computed attributes describing a
+// pipeline, shared by the pipeline data sources.
+func pipelineAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"number": schema.Int64Attribute{
+			Computed:    true,
+			Description: "Pipeline number within the repository",
+		},
+		"branch": schema.StringAttribute{
+			Computed:    true,
+			Description: "Branch the pipeline ran on",
+		},
+		"id": schema.Int64Attribute{
+			Computed:    true,
+			Description: "Pipeline ID",
+		},
+		"parent": schema.Int64Attribute{
+			Computed:    true,
+			Description: "Number of the pipeline this pipeline was started from (e.g. for deployments)",
+		},
+		"event": schema.StringAttribute{
+			Computed:    true,
+			Description: "Event that started the pipeline (e.g. push, pull_request, tag, deployment, cron, manual)",
+		},
+		"status": schema.StringAttribute{
+			Computed:    true,
+			Description: "Pipeline status (e.g. pending, running, success, failure)",
+		},
+		"error": schema.StringAttribute{
+			Computed:    true,
+			Description: "Error the pipeline failed with",
+		},
+		"deploy_to": schema.StringAttribute{
+			Computed:    true,
+			Description: "Environment deployed to by deployment pipelines",
+		},
+		"ref": schema.StringAttribute{
+			Computed:    true,
+			Description: "Git reference of the pipeline (e.g. refs/heads/main)",
+		},
+		"commit": schema.StringAttribute{
+			Computed:    true,
+			Description: "SHA of the commit the pipeline ran on",
+		},
+		"title": schema.StringAttribute{
+			Computed:    true,
+			Description: "Pipeline title (e.g. the pull request title)",
+		},
+		"message": schema.StringAttribute{
+			Computed:    true,
+			Description: "Commit message",
+		},
+		"author": schema.StringAttribute{
+			Computed:    true,
+			Description: "Commit author",
+		},
+		"author_email": schema.StringAttribute{
+			Computed:    true,
+			Description: "Email of the commit author",
+		},
+		"author_avatar": schema.StringAttribute{
+			Computed:    true,
+			Description: "Avatar URL of the commit author",
+		},
+		"sender": schema.StringAttribute{
+			Computed:    true,
+			Description: "User that caused the pipeline to start",
+		},
+		"link": schema.StringAttribute{
+			Computed:    true,
+			Description: "Link to the pipeline's commit in the forge",
+		},
+		"created": schema.Int64Attribute{
+			Computed:    true,
+			Description: "Time the pipeline was created (Unix timestamp)",
+		},
+		"enqueued": schema.Int64Attribute{
+			Computed:    true,
+			Description: "Time the pipeline was queued (Unix timestamp)",
+		},
+		"started": schema.Int64Attribute{
+			Computed:    true,
+			Description: "Time the pipeline started (Unix timestamp)",
+		},
+		"finished": schema.Int64Attribute{
+			Computed:    true,
+			Description: "Time the pipeline finished (Unix timestamp)",
+		},
+	}
+}
@@ -0,0 +1,168 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func NewDataSourcePipelineLogs() datasource.DataSource {
+	return &DataSourcePipelineLogs{}
+}
+
+type DataSourcePipelineLogs struct {
+	client woodpecker.Client
+}
+
+func (d *DataSourcePipelineLogs) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_logs"
+}
+
+func (r DataSourcePipelineLogs) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get the log of a pipeline step",
+
+		Attributes: map[string]schema.Attribute{
+
+			// Required Attributes
+			"repo_owner": schema.StringAttribute{
+				Required:    true,
+				Description: "User or organization responsible for repository",
+			},
+			"repo_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Repository name",
+			},
+			"number": schema.Int64Attribute{
+				Required:    true,
+				Description: "Pipeline number",
+			},
+			"step": schema.StringAttribute{
+				Required:    true,
+				Description: "Step name",
+			},
+
+			// Optional Attributes
+			"workflow": schema.StringAttribute{
+				Optional:    true,
+				Description: "Workflow name, required when multiple workflows have a step with the given name",
+			},
+
+			// Computed Attributes
+			"step_pid": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Step process ID within the pipeline",
+			},
+			"lines": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Log lines of the step",
+			},
+		},
+	}
+}
+
+func (r *DataSourcePipelineLogs) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*woodpeckerProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *woodpeckerProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r DataSourcePipelineLogs) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errProviderUnconfigured.Error())
+		return
+	}
+
+	// unmarshall request config into resourceData
+	var resourceData PipelineLogs
+	diags := req.Config.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repoOwner := resourceData.RepoOwner.ValueString()
+	repoName := resourceData.RepoName.ValueString()
+	number := int(resourceData.Number.ValueInt64())
+
+	pipeline, err := r.client.Pipeline(repoOwner, repoName, number)
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error retrieving pipeline", err)
+		return
+	}
+
+	step, err := findPipelineStep(pipeline, resourceData.Workflow.ValueString(), resourceData.Step.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving pipeline step", err.Error())
+		return
+	}
+
+	logs, err := r.client.PipelineLogs(repoOwner, repoName, number, step.PID)
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error retrieving pipeline logs", err)
+		return
+	}
+
+	resourceData.StepPID = types.Int64Value(int64(step.PID))
+	resourceData.Lines = make([]string, len(logs))
+
+	for i, line := range logs {
+		resourceData.Lines[i] = strings.TrimSuffix(line.Output, "\n")
+	}
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+// findPipelineStep looks up a step by name, optionally limited to the
+// workflow with the given name.
+func findPipelineStep(pipeline *woodpecker.Pipeline, workflow, name string) (*woodpecker.Step, error) {
+	var found *woodpecker.Step
+
+	for _, wWorkflow := range pipeline.Steps {
+		if workflow != "" && wWorkflow.Name != workflow {
+			continue
+		}
+
+		for _, step := range wWorkflow.Children {
+			if step.Name != name {
+				continue
+			}
+
+			if found != nil {
+				return nil, fmt.Errorf("multiple workflows of pipeline #%d have a step named %q, set workflow to select one", pipeline.Number, name)
+			}
+
+			found = step
+		}
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("pipeline #%d has no step named %q", pipeline.Number, name)
+	}
+
+	return found, nil
+}
@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func TestAccDataPipelineLogs(t *testing.T) {
+	pollInterval := pipelinePollInterval
+	pipelinePollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { pipelinePollInterval = pollInterval })
+
+	name := "data.woodpecker_pipeline_logs.test_logs"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccFake(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: pipelineLogsDataConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "step_pid", "3"),
+					resource.TestCheckResourceAttr(name, "lines.#", "2"),
+					resource.TestCheckResourceAttr(name, "lines.0", "+ build"),
+				),
+			},
+		},
+	})
+}
+
+func TestFindPipelineStep(t *testing.T) {
+	pipeline := &woodpecker.Pipeline{Number: 1, Steps: []*woodpecker.Step{
+		{PID: 1, Name: "build", Children: []*woodpecker.Step{{PID: 2, Name: "clone"}, {PID: 3, Name: "test"}}},
+		{PID: 4, Name: "lint", Children: []*woodpecker.Step{{PID: 5, Name: "clone"}, {PID: 6, Name: "lint"}}},
+	}}
+
+	if step, err := findPipelineStep(pipeline, "", "test"); err != nil || step.PID != 3 {
+		t.Errorf("unexpected step: %+v, %v", step, err)
+	}
+
+	if step, err := findPipelineStep(pipeline, "lint", "clone"); err != nil || step.PID != 5 {
+		t.Errorf("unexpected step: %+v, %v", step, err)
+	}
+
+	if _, err := findPipelineStep(pipeline, "", "clone"); err == nil {
+		t.Error("expected ambiguous step name to be rejected")
+	}
+
+	if _, err := findPipelineStep(pipeline, "build", "lint"); err == nil {
+		t.Error("expected missing step to be rejected")
+	}
+}
+
+const pipelineLogsDataConfig = `
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+resource "woodpecker_pipeline_trigger" "test_pipeline" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	wait       = true
+}
+data "woodpecker_pipeline_logs" "test_logs" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	number     = woodpecker_pipeline_trigger.test_pipeline.number
+	step       = "build"
+}
+`
@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataPipeline(t *testing.T) {
+	pollInterval := pipelinePollInterval
+	pipelinePollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { pipelinePollInterval = pollInterval })
+
+	name := "data.woodpecker_pipeline.test_pipeline"
+	latest := "data.woodpecker_pipeline.test_latest"
+	defaultBranch := "data.woodpecker_pipeline.test_default_branch"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccFake(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: pipelineDataConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "number", "1"),
+					resource.TestCheckResourceAttr(name, "branch", "main"),
+					resource.TestCheckResourceAttr(name, "status", "success"),
+					resource.TestCheckResourceAttr(name, "author", "test_user"),
+					resource.TestCheckResourceAttrSet(name, "commit"),
+					resource.TestCheckResourceAttrSet(name, "finished"),
+					resource.TestCheckResourceAttr(name, "workflows.#", "1"),
+					resource.TestCheckResourceAttr(name, "workflows.0.steps.1.name", "build"),
+					resource.TestCheckResourceAttr(name, "workflows.0.steps.1.state", "success"),
+					resource.TestCheckResourceAttrPair(latest, "id", name, "id"),
+					resource.TestCheckResourceAttrPair(defaultBranch, "id", name, "id"),
+				),
+			},
+			// The lookup is either by number or branch
+			{
+				Config:      pipelineDataInvalidConfig,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+		},
+	})
+}
+
+const pipelineDataConfig = `
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+resource "woodpecker_pipeline_trigger" "test_pipeline" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	wait       = true
+}
+data "woodpecker_pipeline" "test_pipeline" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	number     = woodpecker_pipeline_trigger.test_pipeline.number
+}
+data "woodpecker_pipeline" "test_latest" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	branch     = "main"
+
+	depends_on = [woodpecker_pipeline_trigger.test_pipeline]
+}
+data "woodpecker_pipeline" "test_default_branch" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+
+	depends_on = [woodpecker_pipeline_trigger.test_pipeline]
+}
+`
+
+const pipelineDataInvalidConfig = `
+data "woodpecker_pipeline" "test_pipeline" {
+	repo_owner = "test_user"
+	repo_name  = "test_repo"
+	number     = 1
+	branch     = "main"
+}
+`
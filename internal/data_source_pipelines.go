@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+// defaultPipelinesLimit is how many pipelines are listed when no limit is
+// configured, matching the size of a page of pipelines in Woodpecker.
+const defaultPipelinesLimit = 50
+
+func NewDataSourcePipelines() datasource.DataSource {
+	return &DataSourcePipelines{}
+}
+
+type DataSourcePipelines struct {
+	client woodpecker.Client
+}
+
+func (d *DataSourcePipelines) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipelines"
+}
+
+func (r DataSourcePipelines) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to list the most recent pipelines of a repository, newest first",
+
+		Attributes: map[string]schema.Attribute{
+
+			// Required Attributes
+			"repo_owner": schema.StringAttribute{
+				Required:    true,
+				Description: "User or organization responsible for repository",
+			},
+			"repo_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Repository name",
+			},
+
+			// Optional Attributes
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include pipelines with this status (e.g. success)",
+				Validators: []validator.String{
+					ValidateStringInSlice{values: []string{
+						"created", "pending", "running", "success", "failure",
+						"killed", "error", "blocked", "declined", "skipped",
+					}},
+				},
+			},
+			"branch": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include pipelines of this branch",
+			},
+			"event": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include pipelines started by this event (one of push, pull_request, tag, deployment, cron, manual)",
+				Validators: []validator.String{
+					ValidateStringInSlice{values: []string{"push", "pull_request", "tag", "deployment", "cron", "manual"}},
+				},
+			},
+			"limit": schema.Int64Attribute{
+				Optional: true,
+				Description: fmt.Sprintf("Maximum number of pipelines to include, "+
+					"defaults to %d. Older pipelines are listed until enough "+
+					"match or none are left.", defaultPipelinesLimit),
+				Validators: []validator.Int64{
+					ValidateInt64AtLeast{min: 1},
+				},
+			},
+
+			// Computed Attributes
+			"pipelines": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Matching pipelines",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: pipelineAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DataSourcePipelines) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*woodpeckerProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *woodpeckerProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r DataSourcePipelines) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errProviderUnconfigured.Error())
+		return
+	}
+
+	// unmarshall request config into resourceData
+	var resourceData Pipelines
+	diags := req.Config.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultPipelinesLimit
+
+	if !resourceData.Limit.IsNull() {
+		limit = int(resourceData.Limit.ValueInt64())
+	}
+
+	resourceData.Pipelines = []PipelineSummary{}
+
+	// pipelines are listed newest first
+	for page := 1; len(resourceData.Pipelines) < limit; page++ {
+		pipelines, err := listPipelinePage(r.client, resourceData.RepoOwner.ValueString(), resourceData.RepoName.ValueString(), page)
+
+		if err != nil {
+			addClientError(&resp.Diagnostics, "Could not list pipelines", err)
+			return
+		}
+
+		if len(pipelines) == 0 {
+			break
+		}
+
+		for _, pipeline := range pipelines {
+			if len(resourceData.Pipelines) == limit {
+				break
+			}
+
+			if !resourceData.Status.IsNull() && pipeline.Status != resourceData.Status.ValueString() {
+				continue
+			}
+
+			if !resourceData.Branch.IsNull() && pipeline.Branch != resourceData.Branch.ValueString() {
+				continue
+			}
+
+			if !resourceData.Event.IsNull() && pipeline.Event != resourceData.Event.ValueString() {
+				continue
+			}
+
+			var summary PipelineSummary
+			WoodpeckerToPipelineSummary(*pipeline, &summary)
+			resourceData.Pipelines = append(resourceData.Pipelines, summary)
+		}
+	}
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,116 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataPipelines(t *testing.T) {
+	pollInterval := pipelinePollInterval
+	pipelinePollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { pipelinePollInterval = pollInterval })
+
+	var fake *fakeWoodpecker
+
+	name := "data.woodpecker_pipelines.test_pipelines"
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			fake = testAccFake(t)
+			// the matching pipeline isn't on the first page
+			fake.pipelinesPerPage = 1
+		},
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			// Create pipelines to list
+			{
+				Config: pipelinesDataSetupConfig,
+			},
+			// Read testing
+			{
+				PreConfig: func() { fake.setPipelineResult("failure") },
+				Config:    pipelinesDataConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "pipelines.#", "1"),
+					resource.TestCheckResourceAttr(name, "pipelines.0.number", "1"),
+					resource.TestCheckResourceAttr(name, "pipelines.0.status", "success"),
+					resource.TestCheckResourceAttr(name, "pipelines.0.event", "manual"),
+					resource.TestCheckResourceAttr(name, "pipelines.0.author", "test_user"),
+				),
+			},
+			// Limit testing
+			{
+				Config: pipelinesDataLimitConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "pipelines.#", "1"),
+					resource.TestCheckResourceAttr(name, "pipelines.0.number", "2"),
+				),
+			},
+		},
+	})
+}
+
+const pipelinesDataSetupConfig = `
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+resource "woodpecker_pipeline_trigger" "test_pipeline" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	wait       = true
+}
+`
+
+const pipelinesDataConfig = `
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+resource "woodpecker_pipeline_trigger" "test_pipeline" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	wait       = true
+}
+resource "woodpecker_pipeline_trigger" "test_failing_pipeline" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+
+	depends_on = [woodpecker_pipeline_trigger.test_pipeline]
+}
+data "woodpecker_pipelines" "test_pipelines" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	branch     = "main"
+	status     = "success"
+	event      = "manual"
+
+	depends_on = [woodpecker_pipeline_trigger.test_failing_pipeline]
+}
+`
+
+const pipelinesDataLimitConfig = `
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+resource "woodpecker_pipeline_trigger" "test_pipeline" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	wait       = true
+}
+resource "woodpecker_pipeline_trigger" "test_failing_pipeline" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+
+	depends_on = [woodpecker_pipeline_trigger.test_pipeline]
+}
+data "woodpecker_pipelines" "test_pipelines" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	limit      = 1
+
+	depends_on = [woodpecker_pipeline_trigger.test_failing_pipeline]
+}
+`
@@ -0,0 +1,215 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func NewDataSourceRepositories() datasource.DataSource {
+	return &DataSourceRepositories{}
+}
+
+type DataSourceRepositories struct {
+	client woodpecker.Client
+}
+
+func (d *DataSourceRepositories) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repositories"
+}
+
+func (r DataSourceRepositories) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to list the repositories visible to the authenticated user",
+
+		Attributes: map[string]schema.Attribute{
+
+			// Optional Attributes
+			"owner": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include repositories of this user or organization",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include repositories whose name matches this regular expression",
+				Validators: []validator.String{
+					ValidateRegex{},
+				},
+			},
+			"active_only": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only include repositories activated in Woodpecker",
+			},
+			"visibility": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include repositories with this visibility (public, private, or internal)",
+				Validators: []validator.String{
+					ValidateStringInSlice{values: []string{"public", "private", "internal"}},
+				},
+			},
+			"is_trusted": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only include repositories that are (or are not) trusted",
+			},
+
+			// Computed Attributes
+			"repositories": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Matching repositories",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Repository ID",
+						},
+						"owner": schema.StringAttribute{
+							Computed:    true,
+							Description: "User or organization responsible for repository",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Repository name",
+						},
+						"full_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "*owner*/*name*",
+						},
+						"avatar": schema.StringAttribute{
+							Computed:    true,
+							Description: "Repository avatar URL",
+						},
+						"link": schema.StringAttribute{
+							Computed:    true,
+							Description: "Link to repository",
+						},
+						"kind": schema.StringAttribute{
+							Computed:    true,
+							Description: "Kind of repository (e.g. git)",
+						},
+						"clone": schema.StringAttribute{
+							Computed:    true,
+							Description: "URL to clone repository",
+						},
+						"branch": schema.StringAttribute{
+							Computed:    true,
+							Description: "Default branch name",
+						},
+						"timeout": schema.Int64Attribute{
+							Computed: true,
+							Description: "After this timeout (in minutes) a pipeline has " +
+								"to finish or will be treated as timed out.",
+						},
+						"visibility": schema.StringAttribute{
+							Computed:    true,
+							Description: "Public, Private, or Internal",
+						},
+						"is_trusted": schema.BoolAttribute{
+							Computed: true,
+							Description: "If true, underlying pipeline containers get " +
+								"access to escalated capabilities like mounting volumes.",
+						},
+						"is_gated": schema.BoolAttribute{
+							Computed:    true,
+							Description: "When true, every pipeline needs to be approved before being executed.",
+						},
+						"allow_pull": schema.BoolAttribute{
+							Computed:    true,
+							Description: "If true, pipelines can run on pull requests.",
+						},
+						"config": schema.StringAttribute{
+							Computed: true,
+							MarkdownDescription: "Path to the pipeline config file or " +
+								"folder. When empty, defaults to `.woodpecker/*.yml` -> " +
+								"`.woodpecker.yml` -> `.drone.yml`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *DataSourceRepositories) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*woodpeckerProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *woodpeckerProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r DataSourceRepositories) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errProviderUnconfigured.Error())
+		return
+	}
+
+	// unmarshall request config into resourceData
+	var resourceData Repositories
+	diags := req.Config.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// without "all", only repositories active in Woodpecker are listed;
+	// the list isn't synced with the forge, as that changes Woodpecker
+	all := !resourceData.ActiveOnly.ValueBool()
+
+	repos, err := r.client.RepoListOpts(false, all)
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Could not list repositories", err)
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+
+	if !resourceData.NameRegex.IsNull() {
+		// already validated during plan
+		nameRegex = regexp.MustCompile(resourceData.NameRegex.ValueString())
+	}
+
+	resourceData.Repositories = []RepositoryData{}
+
+	for _, repo := range repos {
+		if !resourceData.Owner.IsNull() && repo.Owner != resourceData.Owner.ValueString() {
+			continue
+		}
+
+		if nameRegex != nil && !nameRegex.MatchString(repo.Name) {
+			continue
+		}
+
+		if !resourceData.Visibility.IsNull() && repo.Visibility != resourceData.Visibility.ValueString() {
+			continue
+		}
+
+		if !resourceData.IsTrusted.IsNull() && repo.IsTrusted != resourceData.IsTrusted.ValueBool() {
+			continue
+		}
+
+		var repository RepositoryData
+		WoodpeckerToRepositoryData(*repo, &repository)
+		resourceData.Repositories = append(resourceData.Repositories, repository)
+	}
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
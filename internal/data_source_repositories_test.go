@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataRepositories(t *testing.T) {
+	name := "data.woodpecker_repositories.test_repos"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: repositoriesDataConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "repositories.#", "1"),
+					resource.TestCheckResourceAttr(name, "repositories.0.full_name", "test_user/test_repo"),
+				),
+			},
+			// The repository hasn't been activated
+			{
+				Config: repositoriesActiveDataConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "repositories.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const repositoriesDataConfig = `
+data "woodpecker_repositories" "test_repos" {
+	owner      = "test_user"
+	name_regex = "^test_repo$"
+}
+`
+
+const repositoriesActiveDataConfig = `
+data "woodpecker_repositories" "test_repos" {
+	owner       = "test_user"
+	name_regex  = "^test_repo$"
+	active_only = true
+}
+`
@@ -13,7 +13,7 @@ func NewDataSourceRepository() datasource.DataSource {
 }
 
 type DataSourceRepository struct {
-	p woodpeckerProvider
+	p *woodpeckerProvider
 }
 
 func (d *DataSourceRepository) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -110,13 +110,17 @@ func (r *DataSourceRepository) Configure(_ context.Context, req datasource.Confi
 		return
 	}
 
-	r.p = *p
+	r.p = p
 }
 
 func (r DataSourceRepository) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if r.p.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errProviderUnconfigured.Error())
+		return
+	}
 
 	// unmarshall request config into resourceData
-	var resourceData Repository
+	var resourceData RepositoryData
 	diags := req.Config.Get(ctx, &resourceData)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -133,7 +137,7 @@ func (r DataSourceRepository) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	WoodpeckerToRepository(*repo, &resourceData)
+	WoodpeckerToRepositoryData(*repo, &resourceData)
 
 	diags = resp.State.Set(ctx, &resourceData)
 	resp.Diagnostics.Append(diags...)
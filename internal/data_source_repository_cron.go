@@ -14,7 +14,8 @@ func NewDataSourceRepositoryCron() datasource.DataSource {
 }
 
 type DataSourceRepositoryCron struct {
-	client woodpecker.Client
+	client       woodpecker.Client
+	capabilities serverCapabilities
 }
 
 func (d *DataSourceRepositoryCron) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -36,8 +37,16 @@ func (r DataSourceRepositoryCron) Schema(ctx context.Context, req datasource.Sch
 				Required:    true,
 				Description: "Repository name",
 			},
+
+			// Optional Attributes (exactly one of id or name)
+			"id": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Cron ID",
+			},
 			"name": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Description: "Cron Name",
 			},
 
@@ -54,10 +63,6 @@ func (r DataSourceRepositoryCron) Schema(ctx context.Context, req datasource.Sch
 				Computed:    true,
 				Description: "",
 			},
-			"id": schema.Int64Attribute{
-				Computed:    true,
-				Description: "",
-			},
 			"next_exec": schema.Int64Attribute{
 				Computed:    true,
 				Description: "",
@@ -74,6 +79,12 @@ func (r DataSourceRepositoryCron) Schema(ctx context.Context, req datasource.Sch
 	}
 }
 
+func (r DataSourceRepositoryCron) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		ValidateExactlyOneOf{attributes: []string{"id", "name"}},
+	}
+}
+
 func (r *DataSourceRepositoryCron) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -92,9 +103,21 @@ func (r *DataSourceRepositoryCron) Configure(_ context.Context, req datasource.C
 	}
 
 	r.client = p.client
+	r.capabilities = p.capabilities
 }
 
 func (r DataSourceRepositoryCron) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errProviderUnconfigured.Error())
+		return
+	}
+
+	r.capabilities.require(featureCrons, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// unmarshall request config into resourceData
 	var resourceData RepositoryCron
 	diags := req.Config.Get(ctx, &resourceData)
@@ -106,9 +129,15 @@ func (r DataSourceRepositoryCron) Read(ctx context.Context, req datasource.ReadR
 	// fetch repo
 	repoOwner := resourceData.RepoOwner.ValueString()
 	repoName := resourceData.RepoName.ValueString()
-	cronId := resourceData.ID.ValueInt64()
 
-	cron, err := r.client.CronGet(repoOwner, repoName, cronId)
+	var cron *woodpecker.Cron
+	var err error
+
+	if !resourceData.Name.IsNull() {
+		cron, err = r.findCron(repoOwner, repoName, resourceData.Name.ValueString())
+	} else {
+		cron, err = r.client.CronGet(repoOwner, repoName, resourceData.ID.ValueInt64())
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError("Error retrieving repository cron", err.Error())
@@ -120,3 +149,20 @@ func (r DataSourceRepositoryCron) Read(ctx context.Context, req datasource.ReadR
 	diags = resp.State.Set(ctx, &resourceData)
 	resp.Diagnostics.Append(diags...)
 }
+
+// findCron looks up a cron by name, as the API only fetches crons by ID.
+func (r DataSourceRepositoryCron) findCron(repoOwner, repoName, name string) (*woodpecker.Cron, error) {
+	crons, err := r.client.CronList(repoOwner, repoName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cron := range crons {
+		if cron.Name == name {
+			return cron, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no cron named %q found in %s/%s", name, repoOwner, repoName)
+}
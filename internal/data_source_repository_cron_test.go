@@ -20,6 +20,7 @@ func TestAccDataRepoCron(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "repo_name", "test_repo"),
 					resource.TestCheckResourceAttr(name, "name", "test_cron"),
 					resource.TestCheckResourceAttr(name, "schedule", "@daily"),
+					resource.TestCheckResourceAttrPair("data.woodpecker_repository_cron.by_id", "name", name, "name"),
 				),
 			},
 		},
@@ -44,4 +45,10 @@ data "woodpecker_repository_cron" "test_repo_cron" {
 	repo_name  = woodpecker_repository_cron.test_repo_cron.repo_name
 	name	   = woodpecker_repository_cron.test_repo_cron.name
 }
+
+data "woodpecker_repository_cron" "by_id" {
+	repo_owner = woodpecker_repository_cron.test_repo_cron.repo_owner
+	repo_name  = woodpecker_repository_cron.test_repo_cron.repo_name
+	id         = woodpecker_repository_cron.test_repo_cron.id
+}
 `
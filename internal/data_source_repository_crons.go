@@ -0,0 +1,156 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func NewDataSourceRepositoryCrons() datasource.DataSource {
+	return &DataSourceRepositoryCrons{}
+}
+
+type DataSourceRepositoryCrons struct {
+	client       woodpecker.Client
+	capabilities serverCapabilities
+}
+
+func (d *DataSourceRepositoryCrons) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repository_crons"
+}
+
+func (r DataSourceRepositoryCrons) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to list the crons of a repository, including crons created outside of Terraform, sorted by name",
+
+		Attributes: map[string]schema.Attribute{
+
+			// Required Attributes
+			"repo_owner": schema.StringAttribute{
+				Required:    true,
+				Description: "User or organization responsible for repository",
+			},
+			"repo_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Repository name",
+			},
+
+			// Computed Attributes
+			"crons": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Crons of the repository",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"repo_owner": schema.StringAttribute{
+							Computed:    true,
+							Description: "User or organization responsible for repository",
+						},
+						"repo_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Repository name",
+						},
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Cron ID",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Cron Name",
+						},
+						"branch": schema.StringAttribute{
+							Computed:    true,
+							Description: "Branch the pipeline runs on, empty for the repository's default branch",
+						},
+						"created": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Time the cron was created (Unix timestamp)",
+						},
+						"creator_id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "ID of the user that created the cron",
+						},
+						"next_exec": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Next time the cron will run (Unix timestamp)",
+						},
+						"repo_id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Repository ID",
+						},
+						"schedule": schema.StringAttribute{
+							Computed:    true,
+							Description: "Schedule (based on UTC)",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *DataSourceRepositoryCrons) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*woodpeckerProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *woodpeckerProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+	r.capabilities = p.capabilities
+}
+
+func (r DataSourceRepositoryCrons) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errProviderUnconfigured.Error())
+		return
+	}
+
+	r.capabilities.require(featureCrons, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// unmarshall request config into resourceData
+	var resourceData RepositoryCrons
+	diags := req.Config.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	crons, err := r.client.CronList(resourceData.RepoOwner.ValueString(), resourceData.RepoName.ValueString())
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Could not list repository crons", err)
+		return
+	}
+
+	sort.Slice(crons, func(i, j int) bool { return crons[i].Name < crons[j].Name })
+
+	resourceData.Crons = make([]RepositoryCron, len(crons))
+
+	for i, wCron := range crons {
+		cron := &resourceData.Crons[i]
+		cron.RepoOwner = resourceData.RepoOwner
+		cron.RepoName = resourceData.RepoName
+		WoodpeckerToRepositoryCron(*wCron, cron)
+	}
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func TestAccDataRepoCrons(t *testing.T) {
+	var fake *fakeWoodpecker
+
+	name := "data.woodpecker_repository_crons.test_repo_crons"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { fake = testAccFake(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			// Create a cron with Terraform
+			{
+				Config: repoCronsDataSetupConfig,
+			},
+			// Read testing, including a cron created in the UI
+			{
+				PreConfig: func() {
+					fake.locked(func() {
+						repo := fake.activeRepo("test_user", "test_repo")
+						id := fake.newID()
+						repo.crons[id] = &woodpecker.Cron{
+							ID:       id,
+							Name:     "ui_cron",
+							RepoID:   repo.repo.ID,
+							Schedule: "@hourly",
+							Branch:   "develop",
+						}
+					})
+				},
+				Config: repoCronsDataConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "crons.#", "2"),
+					resource.TestCheckResourceAttr(name, "crons.0.name", "test_cron"),
+					resource.TestCheckResourceAttr(name, "crons.0.schedule", "@daily"),
+					resource.TestCheckResourceAttr(name, "crons.0.repo_owner", "test_user"),
+					resource.TestCheckResourceAttr(name, "crons.0.repo_name", "test_repo"),
+					resource.TestCheckResourceAttrPair(name, "crons.0.id", "woodpecker_repository_cron.test_repo_cron", "id"),
+					resource.TestCheckResourceAttr(name, "crons.1.name", "ui_cron"),
+					resource.TestCheckResourceAttr(name, "crons.1.schedule", "@hourly"),
+					resource.TestCheckResourceAttr(name, "crons.1.branch", "develop"),
+				),
+			},
+		},
+	})
+}
+
+const repoCronsDataSetupConfig = `
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+
+resource "woodpecker_repository_cron" "test_repo_cron" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	name       = "test_cron"
+	schedule   = "@daily"
+}
+`
+
+const repoCronsDataConfig = repoCronsDataSetupConfig + `
+data "woodpecker_repository_crons" "test_repo_crons" {
+	repo_owner = woodpecker_repository_cron.test_repo_cron.repo_owner
+	repo_name  = woodpecker_repository_cron.test_repo_cron.repo_name
+	depends_on = [woodpecker_repository_cron.test_repo_cron]
+}
+`
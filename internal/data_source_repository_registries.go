@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func NewDataSourceRepositoryRegistries() datasource.DataSource {
+	return &DataSourceRepositoryRegistries{}
+}
+
+type DataSourceRepositoryRegistries struct {
+	client woodpecker.Client
+}
+
+func (d *DataSourceRepositoryRegistries) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repository_registries"
+}
+
+func (r DataSourceRepositoryRegistries) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to list the registries a repository authenticates to, sorted by address",
+
+		Attributes: map[string]schema.Attribute{
+			// Required Attributes
+			"repo_owner": schema.StringAttribute{
+				Required:    true,
+				Description: "User or organization responsible for repository",
+			},
+			"repo_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Repository name",
+			},
+
+			// Computed Attributes
+			"registries": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Registries of the repository",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"repo_owner": schema.StringAttribute{
+							Computed:    true,
+							Description: "User or organization responsible for repository",
+						},
+						"repo_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Repository name",
+						},
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Registry ID",
+						},
+						"address": schema.StringAttribute{
+							Computed:    true,
+							Description: "Registry Address",
+						},
+						"username": schema.StringAttribute{
+							Computed:    true,
+							Description: "Registry Username",
+						},
+						"token": schema.StringAttribute{
+							Computed:    true,
+							Description: "Registry Token",
+							Sensitive:   true,
+						},
+						"email": schema.StringAttribute{
+							Computed:    true,
+							Description: "Registry Email",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *DataSourceRepositoryRegistries) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*woodpeckerProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *woodpeckerProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r DataSourceRepositoryRegistries) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errProviderUnconfigured.Error())
+		return
+	}
+
+	// unmarshall request config into resourceData
+	var resourceData RepositoryRegistries
+	diags := req.Config.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	registries, err := r.client.RegistryList(resourceData.RepoOwner.ValueString(), resourceData.RepoName.ValueString())
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Could not list repository registries", err)
+		return
+	}
+
+	sort.Slice(registries, func(i, j int) bool { return registries[i].Address < registries[j].Address })
+
+	resourceData.Registries = make([]RepositoryRegistryData, len(registries))
+
+	for i, wRegistry := range registries {
+		registry := &resourceData.Registries[i]
+		registry.RepoOwner = resourceData.RepoOwner
+		registry.RepoName = resourceData.RepoName
+
+		diags = DataSourceRepositoryRegistry{}.WoodpeckerToRepositoryRegistryData(ctx, *wRegistry, registry)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,60 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataRepoRegistries(t *testing.T) {
+	name := "data.woodpecker_repository_registries.test_repo_registries"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: repoRegistriesDataConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "registries.#", "2"),
+					resource.TestCheckResourceAttr(name, "registries.0.address", "docker.io"),
+					resource.TestCheckResourceAttr(name, "registries.0.username", "reg_test_user"),
+					resource.TestCheckResourceAttr(name, "registries.0.repo_owner", "test_user"),
+					resource.TestCheckResourceAttr(name, "registries.0.repo_name", "test_repo"),
+					resource.TestCheckNoResourceAttr(name, "registries.0.password"),
+					resource.TestCheckResourceAttr(name, "registries.1.address", "ghcr.io"),
+					resource.TestCheckResourceAttrPair(name, "registries.1.id", "woodpecker_repository_registry.ghcr", "id"),
+				),
+			},
+		},
+	})
+}
+
+const repoRegistriesDataConfig = `
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+
+resource "woodpecker_repository_registry" "ghcr" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	address    = "ghcr.io"
+	username   = "reg_test_user"
+	password   = "reg_test_pass"
+}
+
+resource "woodpecker_repository_registry" "docker" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	address    = "docker.io"
+	username   = "reg_test_user"
+	password   = "reg_test_pass"
+}
+
+data "woodpecker_repository_registries" "test_repo_registries" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	depends_on = [woodpecker_repository_registry.ghcr, woodpecker_repository_registry.docker]
+}
+`
@@ -37,16 +37,20 @@ func (r DataSourceRepositoryRegistry) Schema(ctx context.Context, req datasource
 				Required:    true,
 				Description: "Repository name",
 			},
+
+			// Optional Attributes (exactly one of id or address)
+			"id": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Registry ID",
+			},
 			"address": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Description: "Registry Address",
 			},
 
 			// Computed Attributes
-			"id": schema.Int64Attribute{
-				Computed:    true,
-				Description: "",
-			},
 			"username": schema.StringAttribute{
 				Computed:    true,
 				Description: "Registry Username",
@@ -64,6 +68,12 @@ func (r DataSourceRepositoryRegistry) Schema(ctx context.Context, req datasource
 	}
 }
 
+func (r DataSourceRepositoryRegistry) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		ValidateExactlyOneOf{attributes: []string{"id", "address"}},
+	}
+}
+
 func (r *DataSourceRepositoryRegistry) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -85,6 +95,11 @@ func (r *DataSourceRepositoryRegistry) Configure(_ context.Context, req datasour
 }
 
 func (r DataSourceRepositoryRegistry) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errProviderUnconfigured.Error())
+		return
+	}
+
 	// unmarshall request config into resourceData
 	var resourceData RepositoryRegistryData
 	diags := req.Config.Get(ctx, &resourceData)
@@ -96,9 +111,15 @@ func (r DataSourceRepositoryRegistry) Read(ctx context.Context, req datasource.R
 	// fetch repo
 	repoOwner := resourceData.RepoOwner.ValueString()
 	repoName := resourceData.RepoName.ValueString()
-	address := resourceData.Address.ValueString()
 
-	registry, err := r.client.Registry(repoOwner, repoName, address)
+	var registry *woodpecker.Registry
+	var err error
+
+	if !resourceData.Address.IsNull() {
+		registry, err = r.client.Registry(repoOwner, repoName, resourceData.Address.ValueString())
+	} else {
+		registry, err = r.findRegistry(repoOwner, repoName, resourceData.ID.ValueInt64())
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError("Error retrieving repository secret", err.Error())
@@ -115,6 +136,24 @@ func (r DataSourceRepositoryRegistry) Read(ctx context.Context, req datasource.R
 	resp.Diagnostics.Append(diags...)
 }
 
+// findRegistry looks up a registry by ID, as the API only fetches
+// registries by address.
+func (r DataSourceRepositoryRegistry) findRegistry(repoOwner, repoName string, id int64) (*woodpecker.Registry, error) {
+	registries, err := r.client.RegistryList(repoOwner, repoName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, registry := range registries {
+		if registry.ID == id {
+			return registry, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no registry with ID %d found in %s/%s", id, repoOwner, repoName)
+}
+
 func (r DataSourceRepositoryRegistry) WoodpeckerToRepositoryRegistryData(ctx context.Context, wRegistry woodpecker.Registry, registry *RepositoryRegistryData) diag.Diagnostics {
 
 	var diags diag.Diagnostics
@@ -21,6 +21,7 @@ func TestAccDataRepoRegistry(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "address", "docker.io"),
 					resource.TestCheckResourceAttr(name, "username", "reg_test_user"),
 					resource.TestCheckNoResourceAttr(name, "password"),
+					resource.TestCheckResourceAttrPair("data.woodpecker_repository_registry.by_id", "address", name, "address"),
 				),
 			},
 		},
@@ -47,4 +48,10 @@ data "woodpecker_repository_registry" "test_repo_registry" {
 	address    = woodpecker_repository_registry.test_repo_registry.address
 	depends_on = [woodpecker_repository_registry.test_repo_registry]
 }
+
+data "woodpecker_repository_registry" "by_id" {
+	repo_owner = woodpecker_repository_registry.test_repo_registry.repo_owner
+	repo_name  = woodpecker_repository_registry.test_repo_registry.repo_name
+	id         = woodpecker_repository_registry.test_repo_registry.id
+}
 `
@@ -37,8 +37,16 @@ func (r DataSourceRepositorySecret) Schema(ctx context.Context, req datasource.S
 				Required:    true,
 				Description: "Repository name",
 			},
+
+			// Optional Attributes (exactly one of id or name)
+			"id": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Secret ID",
+			},
 			"name": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Description: "Secret Name",
 			},
 
@@ -57,14 +65,16 @@ func (r DataSourceRepositorySecret) Schema(ctx context.Context, req datasource.S
 				Computed:    true,
 				Description: "One or more event types where secret is available (push, tag, pull_request, deployment, cron, manual)",
 			},
-			"id": schema.Int64Attribute{
-				Computed:    true,
-				Description: "",
-			},
 		},
 	}
 }
 
+func (r DataSourceRepositorySecret) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		ValidateExactlyOneOf{attributes: []string{"id", "name"}},
+	}
+}
+
 func (r *DataSourceRepositorySecret) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -86,6 +96,11 @@ func (r *DataSourceRepositorySecret) Configure(_ context.Context, req datasource
 }
 
 func (r DataSourceRepositorySecret) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errProviderUnconfigured.Error())
+		return
+	}
+
 	// unmarshall request config into resourceData
 	var resourceData RepositorySecretData
 	diags := req.Config.Get(ctx, &resourceData)
@@ -99,7 +114,20 @@ func (r DataSourceRepositorySecret) Read(ctx context.Context, req datasource.Rea
 	repoName := resourceData.RepoName.ValueString()
 	secretName := resourceData.Name.ValueString()
 
-	secret, err := r.client.Secret(repoOwner, repoName, secretName)
+	var secret *woodpecker.Secret
+	var err error
+
+	if !resourceData.Name.IsNull() {
+		secret, err = r.client.Secret(repoOwner, repoName, secretName)
+	} else {
+		var secrets []*woodpecker.Secret
+		secrets, err = r.client.SecretList(repoOwner, repoName)
+		secret = findSecretByID(secrets, resourceData.ID.ValueInt64())
+
+		if err == nil && secret == nil {
+			err = fmt.Errorf("no secret with ID %d found", resourceData.ID.ValueInt64())
+		}
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError("Error retrieving repository secret", err.Error())
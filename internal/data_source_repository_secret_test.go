@@ -21,6 +21,7 @@ func TestAccDataRepoSecret(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "name", "test_secret"),
 					resource.TestCheckResourceAttr(name, "events.#", "1"),
 					resource.TestCheckResourceAttr(name, "events.0", "push"),
+					resource.TestCheckResourceAttrPair("data.woodpecker_repository_secret.by_id", "name", name, "name"),
 				),
 			},
 		},
@@ -47,4 +48,10 @@ data "woodpecker_repository_secret" "test_secret" {
 	name       = woodpecker_repository_secret.test_secret.name
 	depends_on = [woodpecker_repository_secret.test_secret]
 }
+
+data "woodpecker_repository_secret" "by_id" {
+	repo_owner = woodpecker_repository_secret.test_secret.repo_owner
+	repo_name  = woodpecker_repository_secret.test_secret.repo_name
+	id         = woodpecker_repository_secret.test_secret.id
+}
 `
@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func NewDataSourceRepositorySecrets() datasource.DataSource {
+	return &DataSourceRepositorySecrets{}
+}
+
+type DataSourceRepositorySecrets struct {
+	client woodpecker.Client
+}
+
+func (d *DataSourceRepositorySecrets) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repository_secrets"
+}
+
+func (r DataSourceRepositorySecrets) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := secretListAttributes()
+
+	// Required Attributes
+	attributes["repo_owner"] = schema.StringAttribute{
+		Required:    true,
+		Description: "User or organization responsible for repository",
+	}
+	attributes["repo_name"] = schema.StringAttribute{
+		Required:    true,
+		Description: "Repository name",
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to list the secrets of a repository, sorted by name. Secret values are never returned",
+
+		Attributes: attributes,
+	}
+}
+
+func (r *DataSourceRepositorySecrets) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*woodpeckerProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *woodpeckerProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r DataSourceRepositorySecrets) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errProviderUnconfigured.Error())
+		return
+	}
+
+	// unmarshall request config into resourceData
+	var resourceData RepositorySecretsData
+	diags := req.Config.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secrets, err := r.client.SecretList(resourceData.RepoOwner.ValueString(), resourceData.RepoName.ValueString())
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Could not list secrets", err)
+		return
+	}
+
+	resourceData.Secrets, diags = WoodpeckerToSecretList(ctx, secrets, resourceData.Event.ValueString(), resourceData.Image.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+// secretListAttributes returns the filters and the computed list shared by
+// the secret list data sources.
+func secretListAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		// Optional Attributes
+		"event": schema.StringAttribute{
+			Optional:    true,
+			Description: "Only include secrets available to this event (one of push, tag, pull_request, deployment, cron, manual)",
+			Validators: []validator.String{
+				ValidateStringInSlice{values: []string{"push", "tag", "pull_request", "deployment", "cron", "manual"}},
+			},
+		},
+		"image": schema.StringAttribute{
+			Optional:    true,
+			Description: "Only include secrets available to this image, compared without its tag",
+		},
+
+		// Computed Attributes
+		"secrets": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "Matching secrets, sorted by name",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Secret ID",
+					},
+					"name": schema.StringAttribute{
+						Computed:    true,
+						Description: "Secret Name",
+					},
+					"plugins_only": schema.BoolAttribute{
+						Computed:    true,
+						Description: "Whether secret is only available for plugins",
+					},
+					"images": schema.SetAttribute{
+						ElementType: types.StringType,
+						Computed:    true,
+						Description: "List of images where this secret is available, empty when available to all images",
+					},
+					"events": schema.SetAttribute{
+						ElementType: types.StringType,
+						Computed:    true,
+						Description: "One or more event types where secret is available (push, tag, pull_request, deployment, cron, manual)",
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func TestSecretAvailable(t *testing.T) {
+	secret := woodpecker.Secret{
+		Events: []string{"push"},
+		Images: []string{"plugins/docker:20", "registry:5000/image"},
+	}
+
+	tests := []struct {
+		event, image string
+		want         bool
+	}{
+		{"", "", true},
+		{"push", "", true},
+		{"pull_request", "", false},
+		{"push", "plugins/docker", true},
+		{"push", "plugins/docker:latest", true},
+		{"push", "plugins/docker@sha256:abc", true},
+		{"push", "registry:5000/image:1.0", true},
+		{"push", "registry", false},
+		{"push", "alpine", false},
+	}
+
+	for _, test := range tests {
+		if got := secretAvailable(secret, test.event, test.image); got != test.want {
+			t.Errorf("secretAvailable(%q, %q) = %v, want %v", test.event, test.image, got, test.want)
+		}
+	}
+
+	if !secretAvailable(woodpecker.Secret{}, "cron", "alpine") {
+		t.Error("secrets without events or images should be available to all")
+	}
+}
+
+func TestAccDataRepoSecrets(t *testing.T) {
+	all := "data.woodpecker_repository_secrets.all"
+	pr := "data.woodpecker_repository_secrets.pull_request"
+	image := "data.woodpecker_repository_secrets.image"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: repoSecretsDataConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(all, "secrets.#", "2"),
+					resource.TestCheckResourceAttr(all, "secrets.0.name", "a_secret"),
+					resource.TestCheckResourceAttr(all, "secrets.1.name", "b_secret"),
+					resource.TestCheckNoResourceAttr(all, "secrets.0.value"),
+					resource.TestCheckResourceAttr(pr, "secrets.#", "1"),
+					resource.TestCheckResourceAttr(pr, "secrets.0.name", "b_secret"),
+					resource.TestCheckResourceAttr(pr, "secrets.0.images.#", "1"),
+					resource.TestCheckResourceAttr(pr, "secrets.0.plugins_only", "true"),
+					resource.TestCheckResourceAttr(image, "secrets.#", "1"),
+					resource.TestCheckResourceAttr(image, "secrets.0.name", "a_secret"),
+				),
+			},
+		},
+	})
+}
+
+const repoSecretsDataConfig = `
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+
+resource "woodpecker_repository_secret" "b_secret" {
+	repo_owner   = woodpecker_repository.test_repo.owner
+	repo_name    = woodpecker_repository.test_repo.name
+	name         = "b_secret"
+	value        = "test_value"
+	events       = ["push", "pull_request"]
+	images       = ["plugins/docker"]
+	plugins_only = true
+}
+
+resource "woodpecker_repository_secret" "a_secret" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	name       = "a_secret"
+	value      = "test_value"
+	events     = ["push"]
+}
+
+data "woodpecker_repository_secrets" "all" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	depends_on = [woodpecker_repository_secret.a_secret, woodpecker_repository_secret.b_secret]
+}
+
+data "woodpecker_repository_secrets" "pull_request" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	event      = "pull_request"
+	depends_on = [woodpecker_repository_secret.a_secret, woodpecker_repository_secret.b_secret]
+}
+
+data "woodpecker_repository_secrets" "image" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	event      = "push"
+	image      = "alpine:3.18"
+	depends_on = [woodpecker_repository_secret.a_secret, woodpecker_repository_secret.b_secret]
+}
+`
@@ -16,7 +16,8 @@ func NewDataSourceSecret() datasource.DataSource {
 }
 
 type DataSourceSecret struct {
-	client woodpecker.Client
+	client       woodpecker.Client
+	capabilities serverCapabilities
 }
 
 func (d *DataSourceSecret) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -28,9 +29,15 @@ func (r DataSourceSecret) Schema(ctx context.Context, req datasource.SchemaReque
 		MarkdownDescription: "Use this data source to get information on an existing global secret",
 
 		Attributes: map[string]schema.Attribute{
-			// Required Attributes
+			// Optional Attributes (exactly one of id or name)
+			"id": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Secret ID",
+			},
 			"name": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Description: "Secret Name",
 			},
 
@@ -49,14 +56,16 @@ func (r DataSourceSecret) Schema(ctx context.Context, req datasource.SchemaReque
 				Computed:    true,
 				Description: "One or more event types where secret is available (push, tag, pull_request, deployment, cron, manual)",
 			},
-			"id": schema.Int64Attribute{
-				Computed:    true,
-				Description: "",
-			},
 		},
 	}
 }
 
+func (r DataSourceSecret) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		ValidateExactlyOneOf{attributes: []string{"id", "name"}},
+	}
+}
+
 func (r *DataSourceSecret) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -75,9 +84,21 @@ func (r *DataSourceSecret) Configure(_ context.Context, req datasource.Configure
 	}
 
 	r.client = p.client
+	r.capabilities = p.capabilities
 }
 
 func (r DataSourceSecret) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errProviderUnconfigured.Error())
+		return
+	}
+
+	r.capabilities.require(featureGlobalSecrets, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// unmarshall request config into resourceData
 	var resourceData SecretData
 	diags := req.Config.Get(ctx, &resourceData)
@@ -89,7 +110,20 @@ func (r DataSourceSecret) Read(ctx context.Context, req datasource.ReadRequest,
 	// fetch repo
 	secretName := resourceData.Name.ValueString()
 
-	secret, err := r.client.GlobalSecret(secretName)
+	var secret *woodpecker.Secret
+	var err error
+
+	if !resourceData.Name.IsNull() {
+		secret, err = r.client.GlobalSecret(secretName)
+	} else {
+		var secrets []*woodpecker.Secret
+		secrets, err = r.client.GlobalSecretList()
+		secret = findSecretByID(secrets, resourceData.ID.ValueInt64())
+
+		if err == nil && secret == nil {
+			err = fmt.Errorf("no secret with ID %d found", resourceData.ID.ValueInt64())
+		}
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError("Error retrieving secret", err.Error())
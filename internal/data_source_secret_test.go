@@ -19,6 +19,7 @@ func TestAccDataSecret(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "name", "test_secret"),
 					resource.TestCheckResourceAttr(name, "events.#", "1"),
 					resource.TestCheckResourceAttr(name, "events.0", "push"),
+					resource.TestCheckResourceAttrPair("data.woodpecker_secret.by_id", "name", name, "name"),
 				),
 			},
 		},
@@ -36,4 +37,8 @@ data "woodpecker_secret" "test_secret" {
 	name = woodpecker_secret.test_secret.name
 	depends_on = [woodpecker_secret.test_secret]
 }
+
+data "woodpecker_secret" "by_id" {
+	id = woodpecker_secret.test_secret.id
+}
 `
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func NewDataSourceSecrets() datasource.DataSource {
+	return &DataSourceSecrets{}
+}
+
+type DataSourceSecrets struct {
+	client       woodpecker.Client
+	capabilities serverCapabilities
+}
+
+func (d *DataSourceSecrets) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secrets"
+}
+
+func (r DataSourceSecrets) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to list the global secrets, sorted by name. Secret values are never returned",
+
+		Attributes: secretListAttributes(),
+	}
+}
+
+func (r *DataSourceSecrets) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*woodpeckerProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *woodpeckerProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+	r.capabilities = p.capabilities
+}
+
+func (r DataSourceSecrets) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errProviderUnconfigured.Error())
+		return
+	}
+
+	r.capabilities.require(featureGlobalSecrets, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// unmarshall request config into resourceData
+	var resourceData SecretsData
+	diags := req.Config.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secrets, err := r.client.GlobalSecretList()
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Could not list global secrets", err)
+		return
+	}
+
+	resourceData.Secrets, diags = WoodpeckerToSecretList(ctx, secrets, resourceData.Event.ValueString(), resourceData.Image.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,40 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSecrets(t *testing.T) {
+	name := "data.woodpecker_secrets.test_secrets"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: secretsDataConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "secrets.#", "1"),
+					resource.TestCheckResourceAttr(name, "secrets.0.name", "test_secret"),
+					resource.TestCheckResourceAttr(name, "secrets.0.events.#", "1"),
+					resource.TestCheckResourceAttr(name, "secrets.0.events.0", "push"),
+				),
+			},
+		},
+	})
+}
+
+const secretsDataConfig = `
+resource "woodpecker_secret" "test_secret" {
+	name   = "test_secret"
+	value  = "test_value"
+	events = ["push"]
+}
+
+data "woodpecker_secrets" "test_secrets" {
+	event      = "push"
+	depends_on = [woodpecker_secret.test_secret]
+}
+`
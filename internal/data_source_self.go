@@ -14,7 +14,7 @@ func NewDataSourceSelf() datasource.DataSource {
 }
 
 type DataSourceSelf struct {
-	p woodpeckerProvider
+	p *woodpeckerProvider
 }
 
 func (d *DataSourceSelf) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -70,12 +70,23 @@ func (r *DataSourceSelf) Configure(_ context.Context, req datasource.ConfigureRe
 		return
 	}
 
-	r.p = *p
+	r.p = p
 }
 
 func (r DataSourceSelf) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if r.p.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errProviderUnconfigured.Error())
+		return
+	}
+
+	self, err := r.p.getSelf()
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to login", err)
+		return
+	}
+
 	var resourceData User
-	self := r.p.self
 
 	// unmarshall self response into resourceData
 	resourceData.ID = types.Int64Value(self.ID)
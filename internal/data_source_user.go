@@ -13,7 +13,7 @@ func NewDataSourceUser() datasource.DataSource {
 }
 
 type DataSourceUser struct {
-	p woodpeckerProvider
+	p *woodpeckerProvider
 }
 
 func (d *DataSourceUser) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -74,10 +74,14 @@ func (r *DataSourceUser) Configure(_ context.Context, req datasource.ConfigureRe
 		return
 	}
 
-	r.p = *p
+	r.p = p
 }
 
 func (r DataSourceUser) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if r.p.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errProviderUnconfigured.Error())
+		return
+	}
 
 	// unmarshall request config into resourceData
 	var resourceData User
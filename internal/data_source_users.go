@@ -0,0 +1,156 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func NewDataSourceUsers() datasource.DataSource {
+	return &DataSourceUsers{}
+}
+
+type DataSourceUsers struct {
+	client woodpecker.Client
+}
+
+func (d *DataSourceUsers) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (r DataSourceUsers) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to list the users of Woodpecker. Requires admin privileges.",
+
+		Attributes: map[string]schema.Attribute{
+
+			// Optional Attributes
+			"admin_only": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only include Woodpecker admins",
+			},
+			"active_only": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only include users that are active in the system",
+			},
+			"login_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include users whose login matches this regular expression",
+				Validators: []validator.String{
+					ValidateRegex{},
+				},
+			},
+
+			// Computed Attributes
+			"users": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Matching users",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "User ID",
+						},
+						"login": schema.StringAttribute{
+							Computed:    true,
+							Description: "Username for user",
+						},
+						"email": schema.StringAttribute{
+							Computed:    true,
+							Description: "Email address for user",
+						},
+						"avatar": schema.StringAttribute{
+							Computed:    true,
+							Description: "Avatar URL for user",
+						},
+						"active": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether user is active in the system",
+						},
+						"admin": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether user is a Woodpecker admin",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *DataSourceUsers) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*woodpeckerProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *woodpeckerProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r DataSourceUsers) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errProviderUnconfigured.Error())
+		return
+	}
+
+	// unmarshall request config into resourceData
+	var resourceData Users
+	diags := req.Config.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	users, err := r.client.UserList()
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Could not list users", err)
+		return
+	}
+
+	var loginRegex *regexp.Regexp
+
+	if !resourceData.LoginRegex.IsNull() {
+		// already validated during plan
+		loginRegex = regexp.MustCompile(resourceData.LoginRegex.ValueString())
+	}
+
+	resourceData.Users = []User{}
+
+	for _, wUser := range users {
+		if resourceData.AdminOnly.ValueBool() && !wUser.Admin {
+			continue
+		}
+
+		if resourceData.ActiveOnly.ValueBool() && !wUser.Active {
+			continue
+		}
+
+		if loginRegex != nil && !loginRegex.MatchString(wUser.Login) {
+			continue
+		}
+
+		var user User
+		WoodpeckerToUser(ctx, *wUser, &user)
+		resourceData.Users = append(resourceData.Users, user)
+	}
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func TestAccDataUsers(t *testing.T) {
+	name := "data.woodpecker_users.admins"
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			fake := testAccFake(t)
+
+			for _, user := range []*woodpecker.User{
+				{Login: "test_admin", Active: true, Admin: true},
+				{Login: "test_inactive_admin", Admin: true},
+				{Login: "test_member", Active: true},
+				{Login: "other_admin", Active: true, Admin: true},
+			} {
+				user.ID = fake.newID()
+				fake.users[user.Login] = user
+			}
+		},
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: usersDataConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.woodpecker_users.all", "users.#", "5"),
+					resource.TestCheckResourceAttr(name, "users.#", "2"),
+					resource.TestCheckResourceAttr(name, "users.0.login", "test_user"),
+					resource.TestCheckResourceAttr(name, "users.0.admin", "true"),
+					resource.TestCheckResourceAttr(name, "users.0.email", "test@localhost"),
+					resource.TestCheckResourceAttr(name, "users.1.login", "test_admin"),
+				),
+			},
+		},
+	})
+}
+
+const usersDataConfig = `
+data "woodpecker_users" "all" {}
+
+data "woodpecker_users" "admins" {
+	admin_only  = true
+	active_only = true
+	login_regex = "^test_"
+}
+`
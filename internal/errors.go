@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// apiError describes a request Woodpecker answered with an unsuccessful
+// status code.
+type apiError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	Body       string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// errorTransport turns unsuccessful responses into an *apiError.
+//
+// The woodpecker-go client only reports failed requests as a formatted
+// string. By failing the round trip instead, the status code and
+// endpoint survive (wrapped in a *url.Error) and can be inspected with
+// errors.As.
+type errorTransport struct {
+	base http.RoundTripper
+}
+
+func (t *errorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// redirects are left to http.Client to follow
+	if resp.StatusCode < http.StatusBadRequest {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	return nil, &apiError{
+		StatusCode: resp.StatusCode,
+		Method:     req.Method,
+		URL:        req.URL.Redacted(),
+		Body:       string(body),
+	}
+}
+
+// isNotFound reports whether err was caused by Woodpecker responding
+// with 404 Not Found.
+func isNotFound(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// addClientError appends an error diagnostic for a failed API call,
+// including the status code and endpoint when they are known.
+func addClientError(diags *diag.Diagnostics, summary string, err error) {
+	var apiErr *apiError
+
+	if errors.As(err, &apiErr) {
+		diags.AddError(summary, fmt.Sprintf(
+			"Woodpecker responded with %d %s to %s %s: %s",
+			apiErr.StatusCode, http.StatusText(apiErr.StatusCode),
+			apiErr.Method, apiErr.URL, apiErr.Body,
+		))
+		return
+	}
+
+	diags.AddError(summary, err.Error())
+}
+
+// addRepositoryChangedError reports that a resource was not found after
+// its repo_owner or repo_name changed. Changing them only follows a
+// repository that was moved, it does not move the resource itself.
+func addRepositoryChangedError(diags *diag.Diagnostics, kind, owner, name string) {
+	diags.AddError("Repository Changed", fmt.Sprintf(
+		"The %s was not found in %s/%s. Changing repo_owner or repo_name "+
+			"only follows a repository that was moved; to use another "+
+			"repository, replace the %s.",
+		kind, owner, name, kind,
+	))
+}
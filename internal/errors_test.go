@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestErrorTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			http.Error(w, "not found", http.StatusNotFound)
+		case "/broken":
+			http.Error(w, "oops", http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &errorTransport{base: http.DefaultTransport}}
+
+	resp, err := client.Get(server.URL + "/ok")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+
+	_, err = client.Get(server.URL + "/missing")
+	if !isNotFound(err) {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+
+	_, err = client.Get(server.URL + "/broken")
+	if err == nil || isNotFound(err) {
+		t.Fatalf("expected server error, got: %v", err)
+	}
+
+	var diags diag.Diagnostics
+	addClientError(&diags, "Could not refresh", err)
+
+	detail := diags[0].Detail()
+	for _, expected := range []string{"500", "GET", server.URL + "/broken", "oops"} {
+		if !strings.Contains(detail, expected) {
+			t.Errorf("expected %q in diagnostic detail, got: %s", expected, detail)
+		}
+	}
+}
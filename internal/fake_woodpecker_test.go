@@ -0,0 +1,1461 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+// fakeWoodpecker is an in-memory stand-in for the Woodpecker REST API,
+// allowing acceptance tests to run without Docker or network access.
+//
+// It mirrors the fixtures created by .ci/reset.sh: an admin test_user
+// owning the (not yet activated) repository test_user/test_repo, and a
+// test_org organization.
+type fakeWoodpecker struct {
+	server *httptest.Server
+	token  string
+
+	// version reported by /version. Like current development builds, the
+	// fake reports no comparable version by default, so the provider
+	// probes which API it serves.
+	version string
+
+	// legacyAPI serves the owner/name based API of Woodpecker before 1.0
+	// instead of the ID based API (see idClient).
+	legacyAPI bool
+
+	// pipelineResult is the status pipelines finish with.
+	pipelineResult string
+
+	// pipelinesPerPage is the number of pipelines listed per page.
+	pipelinesPerPage int
+
+	queuePaused bool
+	logLevel    string
+
+	mu     sync.Mutex
+	nextID int64
+	faults []*fakeFault
+
+	self          string
+	users         map[string]*woodpecker.User
+	forgeRepos    []*woodpecker.Repo
+	repos         map[int64]*fakeRepo
+	orgs          map[string]int64
+	orgSecrets    map[int64]map[string]*woodpecker.Secret
+	globalSecrets map[string]*woodpecker.Secret
+	agents        map[int64]*woodpecker.Agent
+}
+
+type fakeRepo struct {
+	repo       *woodpecker.Repo
+	secrets    map[string]*woodpecker.Secret
+	registries map[string]*woodpecker.Registry
+	crons      map[int64]*woodpecker.Cron
+	pipelines  []*fakePipeline
+	userID     int64
+	repairs    int
+	inactive   bool
+}
+
+// fakePipeline advances through pending and running to result, one step
+// each time it is fetched.
+type fakePipeline struct {
+	pipeline *woodpecker.Pipeline
+	result   string
+}
+
+// fakeFault makes requests matching method and path fail with status.
+type fakeFault struct {
+	method    string
+	path      *regexp.Regexp
+	status    int
+	remaining int
+}
+
+// newFakeWoodpecker starts a fake Woodpecker server that is shut down
+// when the test completes.
+func newFakeWoodpecker(t testing.TB) *fakeWoodpecker {
+	f := &fakeWoodpecker{
+		token:            "fake-token",
+		version:          "next-fake",
+		pipelineResult:   "success",
+		pipelinesPerPage: 50,
+		logLevel:         "info",
+		self:             "test_user",
+		users:            map[string]*woodpecker.User{},
+		repos:            map[int64]*fakeRepo{},
+		orgs:             map[string]int64{},
+		orgSecrets:       map[int64]map[string]*woodpecker.Secret{},
+		globalSecrets:    map[string]*woodpecker.Secret{},
+		agents:           map[int64]*woodpecker.Agent{},
+	}
+
+	f.users["test_user"] = &woodpecker.User{
+		ID:     f.newID(),
+		Login:  "test_user",
+		Email:  "test@localhost",
+		Active: true,
+		Admin:  true,
+	}
+
+	f.orgs["test_user"] = f.newID()
+	f.orgs["test_org"] = f.newID()
+
+	f.forgeRepos = append(f.forgeRepos, f.newForgeRepo("test_user", "test_repo"))
+
+	f.server = httptest.NewServer(f)
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+// use points the provider at the fake server for the rest of the test.
+// Setting WOODPECKER_FAKE_API=legacy runs the test against the owner/name
+// based API.
+func (f *fakeWoodpecker) use(t *testing.T) {
+	f.legacyAPI = os.Getenv("WOODPECKER_FAKE_API") == "legacy"
+
+	t.Setenv("WOODPECKER_SERVER", f.server.URL)
+	t.Setenv("WOODPECKER_TOKEN", f.token)
+	t.Setenv("WOODPECKER_RETRY_MIN_WAIT", "1ms")
+	t.Setenv("WOODPECKER_RETRY_MAX_WAIT", "5ms")
+}
+
+// fail makes the next times requests matching method and the path regexp
+// fail with status. A negative times fails every matching request.
+func (f *fakeWoodpecker) fail(method, path string, status, times int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.faults = append(f.faults, &fakeFault{
+		method:    method,
+		path:      regexp.MustCompile(path),
+		status:    status,
+		remaining: times,
+	})
+}
+
+// locked runs fn holding the lock, for tests changing or inspecting the
+// fake while the provider may still be talking to it.
+func (f *fakeWoodpecker) locked(fn func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	fn()
+}
+
+// setPipelineResult sets the status pipelines created from now on finish
+// with.
+func (f *fakeWoodpecker) setPipelineResult(result string) {
+	f.locked(func() { f.pipelineResult = result })
+}
+
+// setSelf authenticates the provider as another user.
+func (f *fakeWoodpecker) setSelf(login string) {
+	f.locked(func() { f.self = login })
+}
+
+// setAdmin promotes or demotes a user.
+func (f *fakeWoodpecker) setAdmin(login string, admin bool) {
+	f.locked(func() { f.users[login].Admin = admin })
+}
+
+func (f *fakeWoodpecker) setQueuePaused(paused bool) {
+	f.locked(func() { f.queuePaused = paused })
+}
+
+func (f *fakeWoodpecker) isQueuePaused() (paused bool) {
+	f.locked(func() { paused = f.queuePaused })
+	return paused
+}
+
+// purgePipelines deletes every pipeline of the repository, as Woodpecker
+// does when its history is purged.
+func (f *fakeWoodpecker) purgePipelines(owner, name string) {
+	f.locked(func() { f.knownRepo(owner, name).pipelines = nil })
+}
+
+// httpClient returns an HTTP client authenticated with the fake server.
+func (f *fakeWoodpecker) httpClient(t *testing.T) *http.Client {
+	transport, err := createTransport(testTransportConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return &http.Client{Transport: &fakeAuthTransport{
+		token: f.token,
+		base:  &errorTransport{base: transport},
+	}}
+}
+
+func (f *fakeWoodpecker) newID() int64 {
+	f.nextID++
+	return f.nextID
+}
+
+func (f *fakeWoodpecker) newForgeRepo(owner, name string) *woodpecker.Repo {
+	return &woodpecker.Repo{
+		Owner:      owner,
+		Name:       name,
+		FullName:   owner + "/" + name,
+		Link:       "http://forge.localhost/" + owner + "/" + name,
+		Kind:       "git",
+		Clone:      "http://forge.localhost/" + owner + "/" + name + ".git",
+		Branch:     "main",
+		Visibility: "public",
+	}
+}
+
+func (f *fakeWoodpecker) idBased() bool {
+	return !f.legacyAPI
+}
+
+func (f *fakeWoodpecker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, fault := range f.faults {
+		if fault.remaining == 0 || fault.method != r.Method || !fault.path.MatchString(r.URL.Path) {
+			continue
+		}
+
+		fault.remaining--
+		http.Error(w, "injected fault", fault.status)
+		return
+	}
+
+	if r.URL.Path == "/version" {
+		writeJSON(w, woodpecker.Version{Source: "fake", Version: f.version})
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+f.token {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var segments []string
+
+	for _, segment := range strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/") {
+		segment, _ = url.PathUnescape(segment)
+		segments = append(segments, segment)
+	}
+
+	if len(segments) < 2 || segments[0] != "api" {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch segments[1] {
+	case "user":
+		f.serveSelf(w, r, segments[2:])
+	case "users":
+		f.serveUsers(w, r, segments[2:])
+	case "repos":
+		f.serveRepos(w, r, segments[2:])
+	case "orgs":
+		f.serveOrgs(w, r, segments[2:])
+	case "secrets":
+		f.serveSecrets(w, r, f.globalSecrets, segments[2:])
+	case "agents":
+		f.serveAgents(w, r, segments[2:])
+	case "queue":
+		f.serveQueue(w, r, segments[2:])
+	case "log-level":
+		f.serveLogLevel(w, r, segments[2:])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, value interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(value); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+func (f *fakeWoodpecker) serveSelf(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, f.users[f.self])
+
+	case len(segments) == 1 && segments[0] == "repos" && r.Method == http.MethodGet:
+		all := r.URL.Query().Get("all") == "true"
+		repos := []interface{}{}
+
+		for i, forgeRepo := range f.forgeRepos {
+			active := f.activeRepo(forgeRepo.Owner, forgeRepo.Name)
+
+			if active == nil && !all {
+				continue
+			}
+
+			repo := forgeRepo
+
+			if active != nil {
+				repo = active.repo
+			}
+
+			repos = append(repos, struct {
+				*woodpecker.Repo
+				ForgeRemoteID string `json:"forge_remote_id"`
+				Active        bool   `json:"active"`
+			}{repo, strconv.Itoa(i), active != nil})
+		}
+
+		writeJSON(w, repos)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeWoodpecker) serveUsers(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			users := []*woodpecker.User{}
+
+			for _, user := range f.users {
+				users = append(users, user)
+			}
+
+			sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+			writeJSON(w, users)
+
+		case http.MethodPost:
+			user := new(woodpecker.User)
+
+			if !readJSON(w, r, user) {
+				return
+			}
+
+			if _, ok := f.users[user.Login]; ok || user.Login == "" {
+				http.Error(w, "invalid login", http.StatusBadRequest)
+				return
+			}
+
+			user.ID = f.newID()
+			user.Active = true
+			f.users[user.Login] = user
+			writeJSON(w, user)
+
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+
+		return
+	}
+
+	user, ok := f.users[segments[0]]
+
+	if !ok || len(segments) > 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, user)
+
+	case http.MethodPatch:
+		patch := new(woodpecker.User)
+
+		if !readJSON(w, r, patch) {
+			return
+		}
+
+		user.Email = patch.Email
+		user.Admin = patch.Admin
+		user.Active = patch.Active
+
+		if patch.Avatar != "" {
+			user.Avatar = patch.Avatar
+		}
+
+		writeJSON(w, user)
+
+	case http.MethodDelete:
+		delete(f.users, user.Login)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeWoodpecker) activeRepo(owner, name string) *fakeRepo {
+	if repo := f.knownRepo(owner, name); repo != nil && !repo.inactive {
+		return repo
+	}
+
+	return nil
+}
+
+// knownRepo returns the repository, even when it was deactivated.
+func (f *fakeWoodpecker) knownRepo(owner, name string) *fakeRepo {
+	for _, repo := range f.repos {
+		if repo.repo.Owner == owner && repo.repo.Name == name {
+			return repo
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeWoodpecker) activate(w http.ResponseWriter, forgeRepo *woodpecker.Repo) {
+	if f.activeRepo(forgeRepo.Owner, forgeRepo.Name) != nil {
+		http.Error(w, "repository is already active", http.StatusConflict)
+		return
+	}
+
+	// deactivated repositories keep their ID, secrets, and pipelines
+	if repo := f.knownRepo(forgeRepo.Owner, forgeRepo.Name); repo != nil {
+		repo.inactive = false
+		writeJSON(w, repo.repo)
+		return
+	}
+
+	repo := *forgeRepo
+	repo.ID = f.newID()
+	repo.Timeout = 60
+	repo.AllowPull = true
+
+	f.repos[repo.ID] = &fakeRepo{
+		repo:       &repo,
+		secrets:    map[string]*woodpecker.Secret{},
+		registries: map[string]*woodpecker.Registry{},
+		crons:      map[int64]*woodpecker.Cron{},
+		userID:     f.users[f.self].ID,
+	}
+
+	writeJSON(w, &repo)
+}
+
+func (f *fakeWoodpecker) serveRepos(w http.ResponseWriter, r *http.Request, segments []string) {
+	var repo *fakeRepo
+
+	switch {
+	case f.idBased() && len(segments) == 0 && r.Method == http.MethodPost:
+		index, err := strconv.Atoi(r.URL.Query().Get("forge_remote_id"))
+
+		if err != nil || index < 0 || index >= len(f.forgeRepos) {
+			http.NotFound(w, r)
+			return
+		}
+
+		f.activate(w, f.forgeRepos[index])
+		return
+
+	case f.idBased() && len(segments) == 3 && segments[0] == "lookup":
+		if repo = f.activeRepo(segments[1], segments[2]); repo == nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		writeJSON(w, repo.repo)
+		return
+
+	case f.idBased() && len(segments) >= 1:
+		id, _ := strconv.ParseInt(segments[0], 10, 64)
+		repo = f.repos[id]
+		segments = segments[1:]
+
+	case !f.idBased() && len(segments) >= 2:
+		repo = f.activeRepo(segments[0], segments[1])
+
+		if repo == nil && len(segments) == 2 && r.Method == http.MethodPost {
+			for _, forgeRepo := range f.forgeRepos {
+				if forgeRepo.Owner == segments[0] && forgeRepo.Name == segments[1] {
+					f.activate(w, forgeRepo)
+					return
+				}
+			}
+		}
+
+		segments = segments[2:]
+	}
+
+	if repo == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if len(segments) == 0 {
+		f.serveRepo(w, r, repo)
+		return
+	}
+
+	switch segments[0] {
+	case "secrets":
+		f.serveSecrets(w, r, repo.secrets, segments[1:])
+	case "registry":
+		f.serveRegistries(w, r, repo, segments[1:])
+	case "cron":
+		f.serveCrons(w, r, repo, segments[1:])
+	case "pipelines":
+		f.servePipelines(w, r, repo, segments[1:])
+	case "logs":
+		f.serveLogs(w, r, repo, segments[1:])
+	case "move":
+		f.moveRepo(w, r, repo)
+	case "chown":
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		repo.userID = f.users[f.self].ID
+		writeJSON(w, repo.repo)
+	case "repair":
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		repo.repairs++
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeWoodpecker) serveRepo(w http.ResponseWriter, r *http.Request, repo *fakeRepo) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, repo.repo)
+
+	case http.MethodPatch:
+		patch := new(woodpecker.RepoPatch)
+
+		if !readJSON(w, r, patch) {
+			return
+		}
+
+		if patch.Config != nil {
+			repo.repo.Config = *patch.Config
+		}
+
+		if patch.IsTrusted != nil {
+			repo.repo.IsTrusted = *patch.IsTrusted
+		}
+
+		if patch.IsGated != nil {
+			repo.repo.IsGated = *patch.IsGated
+		}
+
+		if patch.Timeout != nil {
+			repo.repo.Timeout = *patch.Timeout
+		}
+
+		if patch.Visibility != nil {
+			repo.repo.Visibility = *patch.Visibility
+		}
+
+		if patch.AllowPull != nil {
+			repo.repo.AllowPull = *patch.AllowPull
+		}
+
+		writeJSON(w, repo.repo)
+
+	case http.MethodDelete:
+		if remove, _ := strconv.ParseBool(r.URL.Query().Get("remove")); remove {
+			delete(f.repos, repo.repo.ID)
+		} else {
+			repo.inactive = true
+		}
+
+		writeJSON(w, repo.repo)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// moveRepo follows a repository that was renamed or transferred in the
+// forge, keeping its ID.
+func (f *fakeWoodpecker) moveRepo(w http.ResponseWriter, r *http.Request, repo *fakeRepo) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	to := strings.SplitN(r.URL.Query().Get("to"), "/", 2)
+
+	if len(to) != 2 {
+		http.Error(w, "invalid target", http.StatusBadRequest)
+		return
+	}
+
+	if f.activeRepo(to[0], to[1]) != nil {
+		http.Error(w, "repository is already active", http.StatusConflict)
+		return
+	}
+
+	for _, forgeRepo := range f.forgeRepos {
+		if forgeRepo.Owner != to[0] || forgeRepo.Name != to[1] {
+			continue
+		}
+
+		repo.repo.Owner = forgeRepo.Owner
+		repo.repo.Name = forgeRepo.Name
+		repo.repo.FullName = forgeRepo.FullName
+		repo.repo.Link = forgeRepo.Link
+		repo.repo.Clone = forgeRepo.Clone
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	http.NotFound(w, r)
+}
+
+func (f *fakeWoodpecker) serveOrgs(w http.ResponseWriter, r *http.Request, segments []string) {
+	if f.idBased() && len(segments) == 2 && segments[0] == "lookup" {
+		id, ok := f.orgs[segments[1]]
+
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		writeJSON(w, map[string]interface{}{"id": id, "name": segments[1]})
+		return
+	}
+
+	if len(segments) < 2 || segments[1] != "secrets" {
+		http.NotFound(w, r)
+		return
+	}
+
+	id, ok := f.orgs[segments[0]]
+
+	if f.idBased() {
+		id, _ = strconv.ParseInt(segments[0], 10, 64)
+		ok = false
+
+		for _, orgID := range f.orgs {
+			ok = ok || orgID == id
+		}
+	}
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if f.orgSecrets[id] == nil {
+		f.orgSecrets[id] = map[string]*woodpecker.Secret{}
+	}
+
+	f.serveSecrets(w, r, f.orgSecrets[id], segments[2:])
+}
+
+// serveSecrets serves repository, organization, and global secrets.
+// Like Woodpecker, secret values are never returned.
+func (f *fakeWoodpecker) serveSecrets(w http.ResponseWriter, r *http.Request, secrets map[string]*woodpecker.Secret, segments []string) {
+	redact := func(secret *woodpecker.Secret) *woodpecker.Secret {
+		redacted := *secret
+		redacted.Value = ""
+		return &redacted
+	}
+
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			list := []*woodpecker.Secret{}
+
+			for _, secret := range secrets {
+				list = append(list, redact(secret))
+			}
+
+			sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+			writeJSON(w, list)
+
+		case http.MethodPost:
+			secret := new(woodpecker.Secret)
+
+			if !readJSON(w, r, secret) {
+				return
+			}
+
+			if _, ok := secrets[secret.Name]; ok || secret.Name == "" || secret.Value == "" {
+				http.Error(w, "invalid secret", http.StatusBadRequest)
+				return
+			}
+
+			secret.ID = f.newID()
+			secrets[secret.Name] = secret
+			writeJSON(w, redact(secret))
+
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+
+		return
+	}
+
+	secret, ok := secrets[segments[0]]
+
+	if !ok || len(segments) > 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, redact(secret))
+
+	case http.MethodPatch:
+		patch := new(woodpecker.Secret)
+
+		if !readJSON(w, r, patch) {
+			return
+		}
+
+		if patch.Value != "" {
+			secret.Value = patch.Value
+		}
+
+		if patch.Images != nil {
+			secret.Images = patch.Images
+		}
+
+		if patch.Events != nil {
+			secret.Events = patch.Events
+		}
+
+		secret.PluginsOnly = patch.PluginsOnly
+		writeJSON(w, redact(secret))
+
+	case http.MethodDelete:
+		delete(secrets, secret.Name)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// serveRegistries serves repository registries. Like Woodpecker,
+// passwords are never returned.
+func (f *fakeWoodpecker) serveRegistries(w http.ResponseWriter, r *http.Request, repo *fakeRepo, segments []string) {
+	redact := func(registry *woodpecker.Registry) *woodpecker.Registry {
+		redacted := *registry
+		redacted.Password = ""
+		return &redacted
+	}
+
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			list := []*woodpecker.Registry{}
+
+			for _, registry := range repo.registries {
+				list = append(list, redact(registry))
+			}
+
+			sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+			writeJSON(w, list)
+
+		case http.MethodPost:
+			registry := new(woodpecker.Registry)
+
+			if !readJSON(w, r, registry) {
+				return
+			}
+
+			if _, ok := repo.registries[registry.Address]; ok || registry.Address == "" {
+				http.Error(w, "invalid registry", http.StatusBadRequest)
+				return
+			}
+
+			registry.ID = f.newID()
+			repo.registries[registry.Address] = registry
+			writeJSON(w, redact(registry))
+
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+
+		return
+	}
+
+	registry, ok := repo.registries[segments[0]]
+
+	if !ok || len(segments) > 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, redact(registry))
+
+	case http.MethodPatch:
+		patch := new(woodpecker.Registry)
+
+		if !readJSON(w, r, patch) {
+			return
+		}
+
+		registry.Username = patch.Username
+		registry.Email = patch.Email
+		registry.Token = patch.Token
+
+		if patch.Password != "" {
+			registry.Password = patch.Password
+		}
+
+		writeJSON(w, redact(registry))
+
+	case http.MethodDelete:
+		delete(repo.registries, registry.Address)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeWoodpecker) serveCrons(w http.ResponseWriter, r *http.Request, repo *fakeRepo, segments []string) {
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			list := []*woodpecker.Cron{}
+
+			for _, repoCron := range repo.crons {
+				list = append(list, repoCron)
+			}
+
+			sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+			writeJSON(w, list)
+
+		case http.MethodPost:
+			repoCron := new(woodpecker.Cron)
+
+			if !readJSON(w, r, repoCron) {
+				return
+			}
+
+			if repoCron.Name == "" || repoCron.Schedule == "" {
+				http.Error(w, "invalid cron", http.StatusBadRequest)
+				return
+			}
+
+			schedule, err := cron.Parse(repoCron.Schedule)
+
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			repoCron.ID = f.newID()
+			repoCron.NextExec = schedule.Next(time.Now().UTC()).Unix()
+			repoCron.RepoID = repo.repo.ID
+			repoCron.CreatorID = f.users[f.self].ID
+			repoCron.Created = time.Now().Unix()
+			repo.crons[repoCron.ID] = repoCron
+			writeJSON(w, repoCron)
+
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+
+		return
+	}
+
+	id, _ := strconv.ParseInt(segments[0], 10, 64)
+	repoCron, ok := repo.crons[id]
+
+	if !ok || len(segments) > 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, repoCron)
+
+	case http.MethodPatch:
+		patch := new(woodpecker.Cron)
+
+		if !readJSON(w, r, patch) {
+			return
+		}
+
+		if patch.Name != "" {
+			repoCron.Name = patch.Name
+		}
+
+		if patch.Schedule != "" {
+			schedule, err := cron.Parse(patch.Schedule)
+
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			repoCron.Schedule = patch.Schedule
+			repoCron.NextExec = schedule.Next(time.Now().UTC()).Unix()
+		}
+
+		if patch.Branch != "" {
+			repoCron.Branch = patch.Branch
+		}
+
+		writeJSON(w, repoCron)
+
+	case http.MethodDelete:
+		delete(repo.crons, repoCron.ID)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeWoodpecker) servePipelines(w http.ResponseWriter, r *http.Request, repo *fakeRepo, segments []string) {
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			list := []*woodpecker.Pipeline{}
+
+			page, err := strconv.Atoi(r.URL.Query().Get("page"))
+
+			if err != nil || page < 1 {
+				page = 1
+			}
+
+			// newest first, without workflows like the real list
+			for i := len(repo.pipelines) - 1 - (page-1)*f.pipelinesPerPage; i >= 0 && len(list) < f.pipelinesPerPage; i-- {
+				pipeline := *repo.pipelines[i].pipeline
+				pipeline.Steps = nil
+				list = append(list, &pipeline)
+			}
+
+			writeJSON(w, list)
+
+		case http.MethodPost:
+			options := new(woodpecker.PipelineOptions)
+
+			if !readJSON(w, r, options) {
+				return
+			}
+
+			if options.Branch == "" {
+				http.Error(w, "branch is required", http.StatusBadRequest)
+				return
+			}
+
+			number := len(repo.pipelines) + 1
+			commit := fmt.Sprintf("%040x", number)
+
+			pipeline := &woodpecker.Pipeline{
+				ID:       f.newID(),
+				Number:   number,
+				Event:    "manual",
+				Created:  time.Now().Unix(),
+				Enqueued: time.Now().Unix(),
+				Commit:   commit,
+				Branch:   options.Branch,
+				Ref:      "refs/heads/" + options.Branch,
+				Message:  "initial commit",
+				Author:   f.self,
+				Email:    f.users[f.self].Email,
+				Sender:   f.self,
+				Link:     repo.repo.Link + "/commit/" + commit,
+			}
+
+			f.writePipeline(w, f.addPipeline(repo, pipeline))
+
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+
+		return
+	}
+
+	var fake *fakePipeline
+
+	if segments[0] == "latest" {
+		branch, ok := r.URL.Query()["branch"]
+
+		// like Woodpecker, an empty branch isn't replaced by the default
+		if !ok {
+			branch = []string{repo.repo.Branch}
+		}
+
+		for i := len(repo.pipelines) - 1; i >= 0 && fake == nil; i-- {
+			if repo.pipelines[i].pipeline.Branch == branch[0] {
+				fake = repo.pipelines[i]
+			}
+		}
+	} else if number, _ := strconv.Atoi(segments[0]); number >= 1 && number <= len(repo.pipelines) {
+		fake = repo.pipelines[number-1]
+	}
+
+	if fake == nil || len(segments) > 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		fake.advance()
+		f.writePipeline(w, fake.pipeline)
+
+	case http.MethodPost:
+		query := r.URL.Query()
+
+		if query.Get("event") != "deployment" || query.Get("deploy_to") == "" {
+			http.Error(w, "only deployments are supported", http.StatusBadRequest)
+			return
+		}
+
+		deployment := *fake.pipeline
+		deployment.ID = f.newID()
+		deployment.Number = len(repo.pipelines) + 1
+		deployment.Parent = fake.pipeline.Number
+		deployment.Event = "deployment"
+		deployment.Deploy = query.Get("deploy_to")
+		deployment.Created = time.Now().Unix()
+
+		f.writePipeline(w, f.addPipeline(repo, &deployment))
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// addPipeline queues pipeline with a single workflow of a clone and a
+// build step.
+func (f *fakeWoodpecker) addPipeline(repo *fakeRepo, pipeline *woodpecker.Pipeline) *woodpecker.Pipeline {
+	pipeline.Started = 0
+	pipeline.Finished = 0
+	pipeline.Steps = []*woodpecker.Step{{
+		ID:       f.newID(),
+		PID:      1,
+		Name:     "woodpecker",
+		Platform: "linux/amd64",
+		Children: []*woodpecker.Step{
+			{ID: f.newID(), PID: 2, PPID: 1, Name: "clone"},
+			{ID: f.newID(), PID: 3, PPID: 1, Name: "build"},
+		},
+	}}
+
+	fake := &fakePipeline{pipeline: pipeline, result: f.pipelineResult}
+	fake.setStatus("pending")
+
+	repo.pipelines = append(repo.pipelines, fake)
+
+	return pipeline
+}
+
+// writePipeline responds with pipeline, listing its workflows the way the
+// server version being faked does.
+func (f *fakeWoodpecker) writePipeline(w http.ResponseWriter, pipeline *woodpecker.Pipeline) {
+	if !f.idBased() {
+		writeJSON(w, pipeline)
+		return
+	}
+
+	withoutSteps := *pipeline
+	withoutSteps.Steps = nil
+
+	writeJSON(w, struct {
+		*woodpecker.Pipeline
+		Workflows []*woodpecker.Step `json:"workflows,omitempty"`
+	}{&withoutSteps, pipeline.Steps})
+}
+
+func (p *fakePipeline) advance() {
+	switch p.pipeline.Status {
+	case "pending":
+		p.pipeline.Started = time.Now().Unix()
+		p.setStatus("running")
+	case "running":
+		p.pipeline.Finished = time.Now().Unix()
+		p.setStatus(p.result)
+	}
+}
+
+func (p *fakePipeline) setStatus(status string) {
+	p.pipeline.Status = status
+
+	for _, workflow := range p.pipeline.Steps {
+		workflow.State = status
+
+		for _, step := range workflow.Children {
+			step.State = status
+		}
+	}
+}
+
+func (f *fakeWoodpecker) serveLogs(w http.ResponseWriter, r *http.Request, repo *fakeRepo, segments []string) {
+	if len(segments) != 2 || r.Method != http.MethodGet {
+		http.NotFound(w, r)
+		return
+	}
+
+	number, _ := strconv.Atoi(segments[0])
+	id, _ := strconv.ParseInt(segments[1], 10, 64)
+
+	if number < 1 || number > len(repo.pipelines) {
+		http.NotFound(w, r)
+		return
+	}
+
+	for _, workflow := range repo.pipelines[number-1].pipeline.Steps {
+		for _, step := range workflow.Children {
+			// steps are addressed by ID since 1.0, by PID before
+			if (f.idBased() && step.ID != id) || (!f.idBased() && int64(step.PID) != id) {
+				continue
+			}
+
+			lines := []string{"+ " + step.Name, step.Name + " done"}
+
+			if !f.idBased() {
+				logs := []*woodpecker.Logs{}
+
+				for _, line := range lines {
+					logs = append(logs, &woodpecker.Logs{Proc: step.Name, Output: line + "\n"})
+				}
+
+				writeJSON(w, logs)
+				return
+			}
+
+			type logEntry struct {
+				StepID int64  `json:"step_id"`
+				Line   int    `json:"line"`
+				Data   []byte `json:"data"`
+			}
+
+			entries := []logEntry{}
+
+			for i, line := range lines {
+				entries = append(entries, logEntry{StepID: step.ID, Line: i, Data: []byte(line)})
+			}
+
+			writeJSON(w, entries)
+			return
+		}
+	}
+
+	http.NotFound(w, r)
+}
+
+func (f *fakeWoodpecker) serveAgents(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			list := []*woodpecker.Agent{}
+
+			for _, agent := range f.agents {
+				list = append(list, agent)
+			}
+
+			sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+			writeJSON(w, list)
+
+		case http.MethodPost:
+			agent := new(woodpecker.Agent)
+
+			if !readJSON(w, r, agent) {
+				return
+			}
+
+			agent.ID = f.newID()
+			agent.OwnerID = f.users[f.self].ID
+			agent.Token = fmt.Sprintf("agent-token-%d", agent.ID)
+			agent.Created = time.Now().Unix()
+			agent.Updated = agent.Created
+			f.agents[agent.ID] = agent
+			writeJSON(w, agent)
+
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+
+		return
+	}
+
+	id, _ := strconv.ParseInt(segments[0], 10, 64)
+	agent, ok := f.agents[id]
+
+	if !ok || len(segments) > 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	heartbeat(agent)
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, agent)
+
+	case http.MethodPatch:
+		patch := new(woodpecker.Agent)
+
+		if !readJSON(w, r, patch) {
+			return
+		}
+
+		agent.Name = patch.Name
+		agent.NoSchedule = patch.NoSchedule
+		agent.Capacity = patch.Capacity
+		agent.Updated = time.Now().Unix()
+		writeJSON(w, agent)
+
+	case http.MethodDelete:
+		delete(f.agents, agent.ID)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// heartbeat reports agent as contacting the server, like a connected agent
+// does every few seconds.
+func heartbeat(agent *woodpecker.Agent) {
+	if now := time.Now().Unix(); now > agent.LastContact {
+		agent.LastContact = now
+	} else {
+		agent.LastContact++
+	}
+
+	agent.Platform = "linux/amd64"
+	agent.Backend = "docker"
+	agent.Version = "next-fake"
+}
+
+// requireAdmin responds with 403 Forbidden unless the user is an admin.
+func (f *fakeWoodpecker) requireAdmin(w http.ResponseWriter) bool {
+	if !f.users[f.self].Admin {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
+
+func (f *fakeWoodpecker) serveQueue(w http.ResponseWriter, r *http.Request, segments []string) {
+	if !f.requireAdmin(w) {
+		return
+	}
+
+	if len(segments) != 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case segments[0] == "info" && r.Method == http.MethodGet:
+		info := woodpecker.Info{Paused: f.queuePaused}
+		info.Stats.Workers = len(f.agents)
+		writeJSON(w, info)
+
+	case segments[0] == "pause" && r.Method == http.MethodPost:
+		f.queuePaused = true
+		w.WriteHeader(http.StatusOK)
+
+	case segments[0] == "resume" && r.Method == http.MethodPost:
+		f.queuePaused = false
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeWoodpecker) serveLogLevel(w http.ResponseWriter, r *http.Request, segments []string) {
+	if !f.requireAdmin(w) {
+		return
+	}
+
+	if len(segments) != 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, woodpecker.LogLevel{Level: f.logLevel})
+
+	case http.MethodPost:
+		logLevel := new(woodpecker.LogLevel)
+
+		if !readJSON(w, r, logLevel) {
+			return
+		}
+
+		f.logLevel = logLevel.Level
+		writeJSON(w, logLevel)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func TestFakeWoodpecker(t *testing.T) {
+	fake := newFakeWoodpecker(t)
+
+	client := newIDClient(nil, fake.httpClient(t), fake.server.URL)
+
+	if _, err := client.Repo("test_user", "test_repo"); !isNotFound(err) {
+		t.Fatalf("expected inactive repository to be missing, got: %v", err)
+	}
+
+	repo, err := client.RepoPost("test_user", "test_repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// transient failures are retried
+	fake.fail(http.MethodGet, `/secrets$`, http.StatusServiceUnavailable, 2)
+
+	secrets, err := client.SecretList("test_user", "test_repo")
+	if err != nil || len(secrets) != 0 {
+		t.Fatalf("unexpected result: %v, %v", secrets, err)
+	}
+
+	_, err = client.SecretCreate("test_user", "test_repo", &woodpecker.Secret{Name: "token", Value: "value", Events: []string{"push"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	secret, err := client.Secret("test_user", "test_repo", "token")
+	if err != nil || secret.Value != "" || secret.Events[0] != "push" {
+		t.Fatalf("unexpected secret: %+v, %v", secret, err)
+	}
+
+	pollInterval := pipelinePollInterval
+	pipelinePollInterval = time.Millisecond
+	defer func() { pipelinePollInterval = pollInterval }()
+
+	pipeline, err := client.PipelineCreate("test_user", "test_repo", &woodpecker.PipelineOptions{Branch: "main"})
+	if err != nil || pipeline.Number != 1 || pipeline.Status != "pending" {
+		t.Fatalf("unexpected pipeline: %+v, %v", pipeline, err)
+	}
+
+	pipeline, err = waitForPipeline(context.Background(), client, "test_user", "test_repo", pipeline)
+	if err != nil || pipeline.Status != "success" {
+		t.Fatalf("unexpected pipeline: %+v, %v", pipeline, err)
+	}
+
+	deployment, err := client.Deploy("test_user", "test_repo", pipeline.Number, "production", map[string]string{"VERSION": "1.0.0"})
+	if err != nil || deployment.Number != 2 || deployment.Parent != 1 || deployment.Deploy != "production" {
+		t.Fatalf("unexpected deployment: %+v, %v", deployment, err)
+	}
+
+	latest, err := client.PipelineLast("test_user", "test_repo", "")
+	if err != nil || latest.Number != 2 || len(latest.Steps) != 1 || len(latest.Steps[0].Children) != 2 {
+		t.Fatalf("unexpected latest pipeline: %+v, %v", latest, err)
+	}
+
+	logs, err := client.PipelineLogs("test_user", "test_repo", 1, 3)
+	if err != nil || len(logs) != 2 || logs[0].Output != "+ build" {
+		t.Fatalf("unexpected logs: %+v, %v", logs, err)
+	}
+
+	fake.locked(func() { fake.repos[repo.ID].userID = 0 })
+
+	_, err = client.RepoChown("test_user", "test_repo")
+
+	var transferred bool
+	fake.locked(func() { transferred = fake.repos[repo.ID].userID == fake.users["test_user"].ID })
+
+	if err != nil || !transferred {
+		t.Fatalf("expected repository to be transferred, got: %v", err)
+	}
+
+	err = client.RepoRepair("test_user", "test_repo")
+
+	var repairs int
+	fake.locked(func() { repairs = fake.repos[repo.ID].repairs })
+
+	if err != nil || repairs != 1 {
+		t.Fatalf("expected repository to be repaired, got: %v", err)
+	}
+
+	fake.locked(func() { fake.forgeRepos = append(fake.forgeRepos, fake.newForgeRepo("test_org", "moved_repo")) })
+
+	if err := client.RepoMove("test_user", "test_repo", "test_org/moved_repo"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if moved, err := client.Repo("test_org", "moved_repo"); err != nil || moved.ID != repo.ID {
+		t.Fatalf("expected repository to keep its ID when moved, got: %+v, %v", moved, err)
+	}
+
+	if _, err := client.Repo("test_user", "test_repo"); !isNotFound(err) {
+		t.Fatalf("expected repository to be moved, got: %v", err)
+	}
+
+	// permanent failures are reported
+	fake.fail(http.MethodDelete, fmt.Sprintf(`/api/repos/%d$`, repo.ID), http.StatusInternalServerError, -1)
+
+	if err := client.RepoDel("test_org", "moved_repo"); err == nil || isNotFound(err) {
+		t.Fatalf("expected injected error, got: %v", err)
+	}
+}
+
+func TestLegacyClientRepoRemove(t *testing.T) {
+	fake := newFakeWoodpecker(t)
+	fake.legacyAPI = true
+
+	client := newLegacyClient(nil, fake.httpClient(t), fake.server.URL)
+
+	if err := client.do(http.MethodPost, "/api/repos/test_user/test_repo", nil, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := client.RepoRemove("test_user", "test_repo"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var repo *fakeRepo
+	fake.locked(func() { repo = fake.knownRepo("test_user", "test_repo") })
+
+	if repo != nil {
+		t.Fatalf("expected repository to be removed, got: %+v", repo.repo)
+	}
+}
+
+// fakeAuthTransport authenticates requests like the oauth2 client used by
+// the provider.
+type fakeAuthTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *fakeAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(req)
+}
@@ -2,6 +2,8 @@ package internal
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -26,6 +28,24 @@ func WoodpeckerToRepository(wRepo woodpecker.Repo, repo *Repository) {
 	repo.Config = types.StringValue(wRepo.Config)
 }
 
+func WoodpeckerToRepositoryData(wRepo woodpecker.Repo, repo *RepositoryData) {
+	repo.ID = types.Int64Value(wRepo.ID)
+	repo.Owner = types.StringValue(wRepo.Owner)
+	repo.Name = types.StringValue(wRepo.Name)
+	repo.FullName = types.StringValue(wRepo.FullName)
+	repo.Avatar = types.StringValue(wRepo.Avatar)
+	repo.Link = types.StringValue(wRepo.Link)
+	repo.Kind = types.StringValue(wRepo.Kind)
+	repo.Clone = types.StringValue(wRepo.Clone)
+	repo.Branch = types.StringValue(wRepo.Branch)
+	repo.Timeout = types.Int64Value(wRepo.Timeout)
+	repo.Visibility = types.StringValue(wRepo.Visibility)
+	repo.IsTrusted = types.BoolValue(wRepo.IsTrusted)
+	repo.IsGated = types.BoolValue(wRepo.IsGated)
+	repo.AllowPull = types.BoolValue(wRepo.AllowPull)
+	repo.Config = types.StringValue(wRepo.Config)
+}
+
 func prepareRepositoryPatch(resourceData Repository) *woodpecker.RepoPatch {
 	patch := woodpecker.RepoPatch{}
 
@@ -362,3 +382,258 @@ func prepareRepositoryRegistryPatch(ctx context.Context, resourceData Repository
 
 	return &patch, diags
 }
+
+func WoodpeckerToAgent(wAgent woodpecker.Agent, agent *Agent) {
+	agent.ID = types.Int64Value(wAgent.ID)
+	agent.Name = types.StringValue(wAgent.Name)
+	agent.OwnerID = types.Int64Value(wAgent.OwnerID)
+	agent.Created = types.Int64Value(wAgent.Created)
+	agent.Updated = types.Int64Value(wAgent.Updated)
+	agent.LastContact = types.Int64Value(wAgent.LastContact)
+	agent.Platform = types.StringValue(wAgent.Platform)
+	agent.Backend = types.StringValue(wAgent.Backend)
+	agent.Capacity = types.Int64Value(int64(wAgent.Capacity))
+	agent.Version = types.StringValue(wAgent.Version)
+	agent.NoSchedule = types.BoolValue(wAgent.NoSchedule)
+
+	// the token is only returned to admins, keep the known one otherwise
+	if wAgent.Token != "" || agent.Token.IsNull() || agent.Token.IsUnknown() {
+		agent.Token = types.StringValue(wAgent.Token)
+	}
+}
+
+func WoodpeckerToAgentData(wAgent woodpecker.Agent, agent *AgentData) {
+	agent.ID = types.Int64Value(wAgent.ID)
+	agent.Name = types.StringValue(wAgent.Name)
+	agent.OwnerID = types.Int64Value(wAgent.OwnerID)
+	agent.Created = types.Int64Value(wAgent.Created)
+	agent.Updated = types.Int64Value(wAgent.Updated)
+	agent.LastContact = types.Int64Value(wAgent.LastContact)
+	agent.Platform = types.StringValue(wAgent.Platform)
+	agent.Backend = types.StringValue(wAgent.Backend)
+	agent.Capacity = types.Int64Value(int64(wAgent.Capacity))
+	agent.Version = types.StringValue(wAgent.Version)
+	agent.NoSchedule = types.BoolValue(wAgent.NoSchedule)
+}
+
+func prepareAgentPatch(resourceData Agent) *woodpecker.Agent {
+	patch := woodpecker.Agent{}
+
+	if !resourceData.ID.IsNull() && !resourceData.ID.IsUnknown() {
+		patch.ID = resourceData.ID.ValueInt64()
+	}
+
+	if !resourceData.Name.IsNull() && !resourceData.Name.IsUnknown() {
+		patch.Name = resourceData.Name.ValueString()
+	}
+
+	if !resourceData.Capacity.IsNull() && !resourceData.Capacity.IsUnknown() {
+		patch.Capacity = int32(resourceData.Capacity.ValueInt64())
+	}
+
+	if !resourceData.NoSchedule.IsNull() && !resourceData.NoSchedule.IsUnknown() {
+		patch.NoSchedule = resourceData.NoSchedule.ValueBool()
+	}
+
+	return &patch
+}
+
+func WoodpeckerToPipelineTrigger(wPipeline woodpecker.Pipeline, pipeline *PipelineTrigger) {
+	pipeline.ID = types.Int64Value(wPipeline.ID)
+	pipeline.Number = types.Int64Value(int64(wPipeline.Number))
+	pipeline.Status = types.StringValue(wPipeline.Status)
+	pipeline.Link = types.StringValue(wPipeline.Link)
+}
+
+func preparePipelineOptions(ctx context.Context, resourceData PipelineTrigger) (*woodpecker.PipelineOptions, diag.Diagnostics) {
+	options := woodpecker.PipelineOptions{Variables: map[string]string{}}
+
+	var diags diag.Diagnostics
+
+	if !resourceData.Branch.IsNull() && !resourceData.Branch.IsUnknown() {
+		options.Branch = resourceData.Branch.ValueString()
+	}
+
+	if !resourceData.Variables.IsNull() && !resourceData.Variables.IsUnknown() {
+		diags = resourceData.Variables.ElementsAs(ctx, &options.Variables, false)
+	}
+
+	return &options, diags
+}
+
+func WoodpeckerToDeployment(wPipeline woodpecker.Pipeline, deployment *Deployment) {
+	deployment.ID = types.Int64Value(wPipeline.ID)
+	deployment.Number = types.Int64Value(int64(wPipeline.Number))
+	deployment.Status = types.StringValue(wPipeline.Status)
+}
+
+func WoodpeckerToPipeline(wPipeline woodpecker.Pipeline, pipeline *Pipeline) {
+	pipeline.Number = types.Int64Value(int64(wPipeline.Number))
+	pipeline.Branch = types.StringValue(wPipeline.Branch)
+	pipeline.ID = types.Int64Value(wPipeline.ID)
+	pipeline.Parent = types.Int64Value(int64(wPipeline.Parent))
+	pipeline.Event = types.StringValue(wPipeline.Event)
+	pipeline.Status = types.StringValue(wPipeline.Status)
+	pipeline.Error = types.StringValue(wPipeline.Error)
+	pipeline.DeployTo = types.StringValue(wPipeline.Deploy)
+	pipeline.Ref = types.StringValue(wPipeline.Ref)
+	pipeline.Commit = types.StringValue(wPipeline.Commit)
+	pipeline.Title = types.StringValue(wPipeline.Title)
+	pipeline.Message = types.StringValue(wPipeline.Message)
+	pipeline.Author = types.StringValue(wPipeline.Author)
+	pipeline.AuthorEmail = types.StringValue(wPipeline.Email)
+	pipeline.AuthorAvatar = types.StringValue(wPipeline.Avatar)
+	pipeline.Sender = types.StringValue(wPipeline.Sender)
+	pipeline.Link = types.StringValue(wPipeline.Link)
+	pipeline.Created = types.Int64Value(wPipeline.Created)
+	pipeline.Enqueued = types.Int64Value(wPipeline.Enqueued)
+	pipeline.Started = types.Int64Value(wPipeline.Started)
+	pipeline.Finished = types.Int64Value(wPipeline.Finished)
+
+	// the API nests steps below the workflow they belong to
+	pipeline.Workflows = []PipelineWorkflow{}
+
+	for _, wWorkflow := range wPipeline.Steps {
+		workflow := PipelineWorkflow{
+			ID:       types.Int64Value(wWorkflow.ID),
+			PID:      types.Int64Value(int64(wWorkflow.PID)),
+			Name:     types.StringValue(wWorkflow.Name),
+			State:    types.StringValue(wWorkflow.State),
+			Error:    types.StringValue(wWorkflow.Error),
+			Started:  types.Int64Value(wWorkflow.Started),
+			Stopped:  types.Int64Value(wWorkflow.Stopped),
+			Platform: types.StringValue(wWorkflow.Platform),
+			Steps:    []PipelineStep{},
+		}
+
+		for _, wStep := range wWorkflow.Children {
+			workflow.Steps = append(workflow.Steps, PipelineStep{
+				ID:       types.Int64Value(wStep.ID),
+				PID:      types.Int64Value(int64(wStep.PID)),
+				Name:     types.StringValue(wStep.Name),
+				State:    types.StringValue(wStep.State),
+				Error:    types.StringValue(wStep.Error),
+				ExitCode: types.Int64Value(int64(wStep.ExitCode)),
+				Started:  types.Int64Value(wStep.Started),
+				Stopped:  types.Int64Value(wStep.Stopped),
+			})
+		}
+
+		pipeline.Workflows = append(pipeline.Workflows, workflow)
+	}
+}
+
+func WoodpeckerToPipelineSummary(wPipeline woodpecker.Pipeline, pipeline *PipelineSummary) {
+	pipeline.Number = types.Int64Value(int64(wPipeline.Number))
+	pipeline.Branch = types.StringValue(wPipeline.Branch)
+	pipeline.ID = types.Int64Value(wPipeline.ID)
+	pipeline.Parent = types.Int64Value(int64(wPipeline.Parent))
+	pipeline.Event = types.StringValue(wPipeline.Event)
+	pipeline.Status = types.StringValue(wPipeline.Status)
+	pipeline.Error = types.StringValue(wPipeline.Error)
+	pipeline.DeployTo = types.StringValue(wPipeline.Deploy)
+	pipeline.Ref = types.StringValue(wPipeline.Ref)
+	pipeline.Commit = types.StringValue(wPipeline.Commit)
+	pipeline.Title = types.StringValue(wPipeline.Title)
+	pipeline.Message = types.StringValue(wPipeline.Message)
+	pipeline.Author = types.StringValue(wPipeline.Author)
+	pipeline.AuthorEmail = types.StringValue(wPipeline.Email)
+	pipeline.AuthorAvatar = types.StringValue(wPipeline.Avatar)
+	pipeline.Sender = types.StringValue(wPipeline.Sender)
+	pipeline.Link = types.StringValue(wPipeline.Link)
+	pipeline.Created = types.Int64Value(wPipeline.Created)
+	pipeline.Enqueued = types.Int64Value(wPipeline.Enqueued)
+	pipeline.Started = types.Int64Value(wPipeline.Started)
+	pipeline.Finished = types.Int64Value(wPipeline.Finished)
+}
+
+func WoodpeckerToQueue(wInfo woodpecker.Info, queue *Queue) {
+	queue.ID = types.StringValue("queue")
+	queue.Paused = types.BoolValue(wInfo.Paused)
+	queue.Pending = types.Int64Value(int64(wInfo.Stats.Pending))
+	queue.WaitingOnDeps = types.Int64Value(int64(wInfo.Stats.WaitingOnDeps))
+	queue.Running = types.Int64Value(int64(wInfo.Stats.Running))
+	queue.Workers = types.Int64Value(int64(wInfo.Stats.Workers))
+}
+
+// findSecretByID returns the secret with the given ID, or nil when it is
+// not in secrets.
+func findSecretByID(secrets []*woodpecker.Secret, id int64) *woodpecker.Secret {
+	for _, secret := range secrets {
+		if secret.ID == id {
+			return secret
+		}
+	}
+
+	return nil
+}
+
+// WoodpeckerToSecretList converts the secrets available to pipelines
+// started by event and running image, sorted by name. Empty filters match
+// every secret. Secret values are never part of the result.
+func WoodpeckerToSecretList(ctx context.Context, wSecrets []*woodpecker.Secret, event, image string) ([]SecretData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	secrets := []SecretData{}
+
+	for _, wSecret := range wSecrets {
+		if !secretAvailable(*wSecret, event, image) {
+			continue
+		}
+
+		var secret SecretData
+		diags.Append(DataSourceSecret{}.WoodpeckerToSecretData(ctx, *wSecret, &secret)...)
+		secrets = append(secrets, secret)
+	}
+
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name.ValueString() < secrets[j].Name.ValueString()
+	})
+
+	return secrets, diags
+}
+
+// secretAvailable mirrors how Woodpecker matches secrets: a secret without
+// events or images is available to all of them, and images are compared
+// without their tag.
+func secretAvailable(secret woodpecker.Secret, event, image string) bool {
+	if event != "" && len(secret.Events) > 0 && !containsString(secret.Events, event) {
+		return false
+	}
+
+	if image == "" || len(secret.Images) == 0 {
+		return true
+	}
+
+	for _, pattern := range secret.Images {
+		if trimImageTag(pattern) == trimImageTag(image) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// trimImageTag strips the tag or digest from an image reference, keeping
+// registry ports (e.g. registry:5000/image:tag becomes registry:5000/image).
+func trimImageTag(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+
+	return image
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
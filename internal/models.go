@@ -5,13 +5,14 @@ import (
 )
 
 type OrganizationSecret struct {
-	Owner       types.String `tfsdk:"owner"`
-	ID          types.Int64  `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Value       types.String `tfsdk:"value"`
-	PluginsOnly types.Bool   `tfsdk:"plugins_only"`
-	Images      types.Set    `tfsdk:"images"`
-	Events      types.Set    `tfsdk:"events"`
+	Owner        types.String `tfsdk:"owner"`
+	ID           types.Int64  `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Value        types.String `tfsdk:"value"`
+	ValueVersion types.Int64  `tfsdk:"value_version"`
+	PluginsOnly  types.Bool   `tfsdk:"plugins_only"`
+	Images       types.Set    `tfsdk:"images"`
+	Events       types.Set    `tfsdk:"events"`
 }
 
 type OrganizationSecretData struct {
@@ -39,25 +40,96 @@ type Repository struct {
 	IsGated    types.Bool   `tfsdk:"is_gated"`
 	AllowPull  types.Bool   `tfsdk:"allow_pull"`
 	Config     types.String `tfsdk:"config"`
+
+	UserID        types.Int64  `tfsdk:"user_id"`
+	OwnerLogin    types.String `tfsdk:"owner_login"`
+	RepairTrigger types.String `tfsdk:"repair_trigger"`
+	DeleteMode    types.String `tfsdk:"delete_mode"`
+}
+
+type RepositoryData struct {
+	ID         types.Int64  `tfsdk:"id"`
+	Owner      types.String `tfsdk:"owner"`
+	Name       types.String `tfsdk:"name"`
+	FullName   types.String `tfsdk:"full_name"`
+	Avatar     types.String `tfsdk:"avatar"`
+	Link       types.String `tfsdk:"link"`
+	Kind       types.String `tfsdk:"kind"`
+	Clone      types.String `tfsdk:"clone"`
+	Branch     types.String `tfsdk:"branch"`
+	Timeout    types.Int64  `tfsdk:"timeout"`
+	Visibility types.String `tfsdk:"visibility"`
+	IsTrusted  types.Bool   `tfsdk:"is_trusted"`
+	IsGated    types.Bool   `tfsdk:"is_gated"`
+	AllowPull  types.Bool   `tfsdk:"allow_pull"`
+	Config     types.String `tfsdk:"config"`
 }
 
 type Secret struct {
+	ID           types.Int64  `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Value        types.String `tfsdk:"value"`
+	ValueVersion types.Int64  `tfsdk:"value_version"`
+	PluginsOnly  types.Bool   `tfsdk:"plugins_only"`
+	Images       types.Set    `tfsdk:"images"`
+	Events       types.Set    `tfsdk:"events"`
+}
+
+type SecretData struct {
 	ID          types.Int64  `tfsdk:"id"`
 	Name        types.String `tfsdk:"name"`
-	Value       types.String `tfsdk:"value"`
 	PluginsOnly types.Bool   `tfsdk:"plugins_only"`
 	Images      types.Set    `tfsdk:"images"`
 	Events      types.Set    `tfsdk:"events"`
 }
 
-type SecretData struct {
+type SecretSetEntry struct {
 	ID          types.Int64  `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
+	Value       types.String `tfsdk:"value"`
 	PluginsOnly types.Bool   `tfsdk:"plugins_only"`
 	Images      types.Set    `tfsdk:"images"`
 	Events      types.Set    `tfsdk:"events"`
 }
 
+type Secrets struct {
+	ID      types.String `tfsdk:"id"`
+	Secrets types.Map    `tfsdk:"secrets"`
+}
+
+type OrganizationSecrets struct {
+	ID      types.String `tfsdk:"id"`
+	Owner   types.String `tfsdk:"owner"`
+	Secrets types.Map    `tfsdk:"secrets"`
+}
+
+type RepositorySecrets struct {
+	ID        types.String `tfsdk:"id"`
+	RepoOwner types.String `tfsdk:"repo_owner"`
+	RepoName  types.String `tfsdk:"repo_name"`
+	Secrets   types.Map    `tfsdk:"secrets"`
+}
+
+type SecretsData struct {
+	Event   types.String `tfsdk:"event"`
+	Image   types.String `tfsdk:"image"`
+	Secrets []SecretData `tfsdk:"secrets"`
+}
+
+type OrganizationSecretsData struct {
+	Owner   types.String `tfsdk:"owner"`
+	Event   types.String `tfsdk:"event"`
+	Image   types.String `tfsdk:"image"`
+	Secrets []SecretData `tfsdk:"secrets"`
+}
+
+type RepositorySecretsData struct {
+	RepoOwner types.String `tfsdk:"repo_owner"`
+	RepoName  types.String `tfsdk:"repo_name"`
+	Event     types.String `tfsdk:"event"`
+	Image     types.String `tfsdk:"image"`
+	Secrets   []SecretData `tfsdk:"secrets"`
+}
+
 type User struct {
 	ID     types.Int64  `tfsdk:"id"`
 	Login  types.String `tfsdk:"login"`
@@ -67,6 +139,13 @@ type User struct {
 	Admin  types.Bool   `tfsdk:"admin"`
 }
 
+type Users struct {
+	AdminOnly  types.Bool   `tfsdk:"admin_only"`
+	ActiveOnly types.Bool   `tfsdk:"active_only"`
+	LoginRegex types.String `tfsdk:"login_regex"`
+	Users      []User       `tfsdk:"users"`
+}
+
 type RepositoryCron struct {
 	RepoOwner types.String `tfsdk:"repo_owner"`
 	RepoName  types.String `tfsdk:"repo_name"`
@@ -80,15 +159,22 @@ type RepositoryCron struct {
 	Branch    types.String `tfsdk:"branch"`
 }
 
+type RepositoryCrons struct {
+	RepoOwner types.String     `tfsdk:"repo_owner"`
+	RepoName  types.String     `tfsdk:"repo_name"`
+	Crons     []RepositoryCron `tfsdk:"crons"`
+}
+
 type RepositorySecret struct {
-	RepoOwner   types.String `tfsdk:"repo_owner"`
-	RepoName    types.String `tfsdk:"repo_name"`
-	ID          types.Int64  `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Value       types.String `tfsdk:"value"`
-	PluginsOnly types.Bool   `tfsdk:"plugins_only"`
-	Images      types.Set    `tfsdk:"images"`
-	Events      types.Set    `tfsdk:"events"`
+	RepoOwner    types.String `tfsdk:"repo_owner"`
+	RepoName     types.String `tfsdk:"repo_name"`
+	ID           types.Int64  `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Value        types.String `tfsdk:"value"`
+	ValueVersion types.Int64  `tfsdk:"value_version"`
+	PluginsOnly  types.Bool   `tfsdk:"plugins_only"`
+	Images       types.Set    `tfsdk:"images"`
+	Events       types.Set    `tfsdk:"events"`
 }
 
 type RepositorySecretData struct {
@@ -102,22 +188,208 @@ type RepositorySecretData struct {
 }
 
 type RepositoryRegistry struct {
+	RepoOwner       types.String `tfsdk:"repo_owner"`
+	RepoName        types.String `tfsdk:"repo_name"`
+	ID              types.Int64  `tfsdk:"id"`
+	Address         types.String `tfsdk:"address"`
+	Username        types.String `tfsdk:"username"`
+	Password        types.String `tfsdk:"password"`
+	PasswordVersion types.Int64  `tfsdk:"password_version"`
+	Token           types.String `tfsdk:"token"`
+	Email           types.String `tfsdk:"email"`
+}
+
+type RepositoryRegistryData struct {
 	RepoOwner types.String `tfsdk:"repo_owner"`
 	RepoName  types.String `tfsdk:"repo_name"`
 	ID        types.Int64  `tfsdk:"id"`
 	Address   types.String `tfsdk:"address"`
 	Username  types.String `tfsdk:"username"`
-	Password  types.String `tfsdk:"password"`
 	Token     types.String `tfsdk:"token"`
 	Email     types.String `tfsdk:"email"`
 }
 
-type RepositoryRegistryData struct {
+type RepositoryRegistries struct {
+	RepoOwner  types.String             `tfsdk:"repo_owner"`
+	RepoName   types.String             `tfsdk:"repo_name"`
+	Registries []RepositoryRegistryData `tfsdk:"registries"`
+}
+
+type Agent struct {
+	ID          types.Int64  `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	OwnerID     types.Int64  `tfsdk:"owner_id"`
+	Token       types.String `tfsdk:"token"`
+	Created     types.Int64  `tfsdk:"created"`
+	Updated     types.Int64  `tfsdk:"updated"`
+	LastContact types.Int64  `tfsdk:"last_contact"`
+	Platform    types.String `tfsdk:"platform"`
+	Backend     types.String `tfsdk:"backend"`
+	Capacity    types.Int64  `tfsdk:"capacity"`
+	Version     types.String `tfsdk:"version"`
+	NoSchedule  types.Bool   `tfsdk:"no_schedule"`
+}
+
+type AgentData struct {
+	ID          types.Int64  `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	OwnerID     types.Int64  `tfsdk:"owner_id"`
+	Created     types.Int64  `tfsdk:"created"`
+	Updated     types.Int64  `tfsdk:"updated"`
+	LastContact types.Int64  `tfsdk:"last_contact"`
+	Platform    types.String `tfsdk:"platform"`
+	Backend     types.String `tfsdk:"backend"`
+	Capacity    types.Int64  `tfsdk:"capacity"`
+	Version     types.String `tfsdk:"version"`
+	NoSchedule  types.Bool   `tfsdk:"no_schedule"`
+}
+
+type Agents struct {
+	Agents []AgentData `tfsdk:"agents"`
+}
+
+type Repositories struct {
+	Owner        types.String     `tfsdk:"owner"`
+	NameRegex    types.String     `tfsdk:"name_regex"`
+	ActiveOnly   types.Bool       `tfsdk:"active_only"`
+	Visibility   types.String     `tfsdk:"visibility"`
+	IsTrusted    types.Bool       `tfsdk:"is_trusted"`
+	Repositories []RepositoryData `tfsdk:"repositories"`
+}
+
+type PipelineTrigger struct {
+	RepoOwner   types.String `tfsdk:"repo_owner"`
+	RepoName    types.String `tfsdk:"repo_name"`
+	Branch      types.String `tfsdk:"branch"`
+	Variables   types.Map    `tfsdk:"variables"`
+	Triggers    types.Map    `tfsdk:"triggers"`
+	Wait        types.Bool   `tfsdk:"wait"`
+	WaitTimeout types.String `tfsdk:"wait_timeout"`
+	ID          types.Int64  `tfsdk:"id"`
+	Number      types.Int64  `tfsdk:"number"`
+	Status      types.String `tfsdk:"status"`
+	Link        types.String `tfsdk:"link"`
+}
+
+type Deployment struct {
+	RepoOwner   types.String `tfsdk:"repo_owner"`
+	RepoName    types.String `tfsdk:"repo_name"`
+	Environment types.String `tfsdk:"environment"`
+	Pipeline    types.Int64  `tfsdk:"pipeline"`
+	Branch      types.String `tfsdk:"branch"`
+	Params      types.Map    `tfsdk:"params"`
+	ID          types.Int64  `tfsdk:"id"`
+	Number      types.Int64  `tfsdk:"number"`
+	Status      types.String `tfsdk:"status"`
+}
+
+type Pipeline struct {
+	RepoOwner    types.String       `tfsdk:"repo_owner"`
+	RepoName     types.String       `tfsdk:"repo_name"`
+	Number       types.Int64        `tfsdk:"number"`
+	Branch       types.String       `tfsdk:"branch"`
+	ID           types.Int64        `tfsdk:"id"`
+	Parent       types.Int64        `tfsdk:"parent"`
+	Event        types.String       `tfsdk:"event"`
+	Status       types.String       `tfsdk:"status"`
+	Error        types.String       `tfsdk:"error"`
+	DeployTo     types.String       `tfsdk:"deploy_to"`
+	Ref          types.String       `tfsdk:"ref"`
+	Commit       types.String       `tfsdk:"commit"`
+	Title        types.String       `tfsdk:"title"`
+	Message      types.String       `tfsdk:"message"`
+	Author       types.String       `tfsdk:"author"`
+	AuthorEmail  types.String       `tfsdk:"author_email"`
+	AuthorAvatar types.String       `tfsdk:"author_avatar"`
+	Sender       types.String       `tfsdk:"sender"`
+	Link         types.String       `tfsdk:"link"`
+	Created      types.Int64        `tfsdk:"created"`
+	Enqueued     types.Int64        `tfsdk:"enqueued"`
+	Started      types.Int64        `tfsdk:"started"`
+	Finished     types.Int64        `tfsdk:"finished"`
+	Workflows    []PipelineWorkflow `tfsdk:"workflows"`
+}
+
+type PipelineSummary struct {
+	Number       types.Int64  `tfsdk:"number"`
+	Branch       types.String `tfsdk:"branch"`
+	ID           types.Int64  `tfsdk:"id"`
+	Parent       types.Int64  `tfsdk:"parent"`
+	Event        types.String `tfsdk:"event"`
+	Status       types.String `tfsdk:"status"`
+	Error        types.String `tfsdk:"error"`
+	DeployTo     types.String `tfsdk:"deploy_to"`
+	Ref          types.String `tfsdk:"ref"`
+	Commit       types.String `tfsdk:"commit"`
+	Title        types.String `tfsdk:"title"`
+	Message      types.String `tfsdk:"message"`
+	Author       types.String `tfsdk:"author"`
+	AuthorEmail  types.String `tfsdk:"author_email"`
+	AuthorAvatar types.String `tfsdk:"author_avatar"`
+	Sender       types.String `tfsdk:"sender"`
+	Link         types.String `tfsdk:"link"`
+	Created      types.Int64  `tfsdk:"created"`
+	Enqueued     types.Int64  `tfsdk:"enqueued"`
+	Started      types.Int64  `tfsdk:"started"`
+	Finished     types.Int64  `tfsdk:"finished"`
+}
+
+type PipelineWorkflow struct {
+	ID       types.Int64    `tfsdk:"id"`
+	PID      types.Int64    `tfsdk:"pid"`
+	Name     types.String   `tfsdk:"name"`
+	State    types.String   `tfsdk:"state"`
+	Error    types.String   `tfsdk:"error"`
+	Started  types.Int64    `tfsdk:"started"`
+	Stopped  types.Int64    `tfsdk:"stopped"`
+	Platform types.String   `tfsdk:"platform"`
+	Steps    []PipelineStep `tfsdk:"steps"`
+}
+
+type PipelineStep struct {
+	ID       types.Int64  `tfsdk:"id"`
+	PID      types.Int64  `tfsdk:"pid"`
+	Name     types.String `tfsdk:"name"`
+	State    types.String `tfsdk:"state"`
+	Error    types.String `tfsdk:"error"`
+	ExitCode types.Int64  `tfsdk:"exit_code"`
+	Started  types.Int64  `tfsdk:"started"`
+	Stopped  types.Int64  `tfsdk:"stopped"`
+}
+
+type Pipelines struct {
+	RepoOwner types.String      `tfsdk:"repo_owner"`
+	RepoName  types.String      `tfsdk:"repo_name"`
+	Status    types.String      `tfsdk:"status"`
+	Branch    types.String      `tfsdk:"branch"`
+	Event     types.String      `tfsdk:"event"`
+	Limit     types.Int64       `tfsdk:"limit"`
+	Pipelines []PipelineSummary `tfsdk:"pipelines"`
+}
+
+type PipelineLogs struct {
 	RepoOwner types.String `tfsdk:"repo_owner"`
 	RepoName  types.String `tfsdk:"repo_name"`
-	ID        types.Int64  `tfsdk:"id"`
-	Address   types.String `tfsdk:"address"`
-	Username  types.String `tfsdk:"username"`
-	Token     types.String `tfsdk:"token"`
-	Email     types.String `tfsdk:"email"`
+	Number    types.Int64  `tfsdk:"number"`
+	Workflow  types.String `tfsdk:"workflow"`
+	Step      types.String `tfsdk:"step"`
+	StepPID   types.Int64  `tfsdk:"step_pid"`
+	Lines     []string     `tfsdk:"lines"`
+}
+
+type Queue struct {
+	ID            types.String `tfsdk:"id"`
+	Paused        types.Bool   `tfsdk:"paused"`
+	Pending       types.Int64  `tfsdk:"pending"`
+	WaitingOnDeps types.Int64  `tfsdk:"waiting_on_deps"`
+	Running       types.Int64  `tfsdk:"running"`
+	Workers       types.Int64  `tfsdk:"workers"`
+
+	PreviouslyPaused types.Bool `tfsdk:"previously_paused"`
+}
+
+type ServerLogLevel struct {
+	ID               types.String `tfsdk:"id"`
+	LogLevel         types.String `tfsdk:"log_level"`
+	PreviousLogLevel types.String `tfsdk:"previous_log_level"`
 }
@@ -2,21 +2,40 @@ package internal
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"os"
+	"strconv"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
 	"golang.org/x/oauth2"
 )
 
+// errProviderUnconfigured is returned when the provider is used before
+// its configuration is known.
+var errProviderUnconfigured = errors.New("the provider configuration " +
+	"depends on values that are not known yet; they will be known after " +
+	"the resources they depend on are applied")
+
 type woodpeckerProvider struct {
 	config providerConfig
 	client woodpecker.Client
+	http   *http.Client
+
+	capabilities serverCapabilities
+
+	// self is looked up on first use, see getSelf
 	self   *woodpecker.User
+	selfMu sync.Mutex
 }
 
 func (p *woodpeckerProvider) Metadata(_ context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -44,7 +63,82 @@ func (p *woodpeckerProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				Optional: true,
 				Description: `Whether to verify SSL certificates when 
 					interacting with Woodpecker CI. It can also be sourced
-					from the WOODPECKER_TOKEN environment variable.`,
+					from the WOODPECKER_VERIFY environment variable.`,
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional: true,
+				Description: "How many times a request failing with a " +
+					"transient error (e.g. 502, 503) is retried. Only " +
+					"requests that are safe to repeat are retried. Defaults " +
+					"to 3, and can also be sourced from the " +
+					"WOODPECKER_MAX_RETRIES environment variable.",
+			},
+			"retry_min_wait": schema.StringAttribute{
+				Optional: true,
+				Description: "Duration to wait before the first retry " +
+					"(e.g. 500ms), doubled on each subsequent retry. " +
+					"Defaults to 1s, and can also be sourced from the " +
+					"WOODPECKER_RETRY_MIN_WAIT environment variable.",
+			},
+			"retry_max_wait": schema.StringAttribute{
+				Optional: true,
+				Description: "Maximum duration to wait between retries, " +
+					"including waits requested through Retry-After. " +
+					"Defaults to 30s, and can also be sourced from the " +
+					"WOODPECKER_RETRY_MAX_WAIT environment variable.",
+			},
+			"read_only": schema.BoolAttribute{
+				Optional: true,
+				Description: "When true, every change to Woodpecker (creating, " +
+					"updating, or deleting resources) fails before a request " +
+					"is sent, while data sources and refreshing state keep " +
+					"working. Defaults to false, and can also be sourced from " +
+					"the WOODPECKER_READ_ONLY environment variable.",
+			},
+			"api_style": schema.StringAttribute{
+				Optional: true,
+				Description: "API the server is addressed through, either id " +
+					"(Woodpecker 1.0 and newer) or legacy (owner/name based). " +
+					"Detected from the server's version by default, development " +
+					"builds are probed. If detection fails, the provider " +
+					"reports an error asking for it to be set. Can also be " +
+					"sourced from the WOODPECKER_API_STYLE environment variable.",
+				Validators: []validator.String{
+					ValidateStringInSlice{values: []string{"id", "legacy"}},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"tls": schema.SingleNestedBlock{
+				Description: "TLS settings used when connecting to Woodpecker CI.",
+				Attributes: map[string]schema.Attribute{
+					"insecure_skip_verify": schema.BoolAttribute{
+						Optional: true,
+						Description: "Skip verification of the server's " +
+							"certificate chain and host name.",
+					},
+					"ca_file": schema.StringAttribute{
+						Optional: true,
+						Description: "Path to a PEM encoded CA bundle used " +
+							"in addition to the system's trusted CAs.",
+					},
+					"ca_pem": schema.StringAttribute{
+						Optional: true,
+						Description: "PEM encoded CA bundle used in addition " +
+							"to the system's trusted CAs.",
+					},
+					"client_cert": schema.StringAttribute{
+						Optional: true,
+						Description: "PEM encoded client certificate (or path " +
+							"to one) used for mutual TLS.",
+					},
+					"client_key": schema.StringAttribute{
+						Optional:  true,
+						Sensitive: true,
+						Description: "PEM encoded private key (or path to " +
+							"one) for client_cert.",
+					},
+				},
 			},
 		},
 	}
@@ -52,25 +146,44 @@ func (p *woodpeckerProvider) Schema(ctx context.Context, req provider.SchemaRequ
 
 func (p *woodpeckerProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewDataSourceAgents,
 		NewDataSourceOrganizationSecret,
+		NewDataSourceOrganizationSecrets,
+		NewDataSourcePipeline,
+		NewDataSourcePipelineLogs,
+		NewDataSourcePipelines,
+		NewDataSourceRepositories,
 		NewDataSourceRepository,
 		NewDataSourceRepositoryCron,
+		NewDataSourceRepositoryCrons,
+		NewDataSourceRepositoryRegistries,
 		NewDataSourceRepositoryRegistry,
 		NewDataSourceRepositorySecret,
+		NewDataSourceRepositorySecrets,
 		NewDataSourceSecret,
+		NewDataSourceSecrets,
 		NewDataSourceSelf,
 		NewDataSourceUser,
+		NewDataSourceUsers,
 	}
 }
 
 func (p *woodpeckerProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewAgentResource,
+		NewDeploymentResource,
 		NewOrganizationSecretResource,
+		NewOrganizationSecretsResource,
+		NewPipelineTriggerResource,
+		NewQueueResource,
 		NewRepositoryResource,
 		NewRepositoryCronResource,
 		NewRepositoryRegistryResource,
 		NewRepositorySecretResource,
+		NewRepositorySecretsResource,
 		NewSecretResource,
+		NewSecretsResource,
+		NewServerLogLevelResource,
 		NewUserResource,
 	}
 }
@@ -82,16 +195,162 @@ func (p *woodpeckerProvider) Configure(ctx context.Context, req provider.Configu
 		return
 	}
 
-	p.client, p.self = p.createClient(ctx, p.config, resp)
-
 	resp.DataSourceData = p
 	resp.ResourceData = p
+
+	if !p.config.isKnown() {
+		// The configuration depends on values that won't be known until
+		// apply (e.g. a Woodpecker instance created in the same run).
+		// Without a client, resources keep their prior state and leave
+		// computed values unknown until the provider can be configured.
+		return
+	}
+
+	p.client, p.http = p.createClient(ctx, p.config, resp)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	capabilities, err := fetchServerCapabilities(p.http, p.config.Server.ValueString())
+	p.capabilities = capabilities
+
+	idBased := p.config.APIStyle.ValueString() == "id"
+
+	switch {
+	case err != nil && !p.config.APIStyle.IsNull():
+		resp.Diagnostics.AddWarning(
+			"Could not detect Woodpecker version",
+			"Features requiring a newer Woodpecker release will not be "+
+				"checked during plan: "+err.Error(),
+		)
+
+	case err != nil:
+		// guessing the API would send requests the server misreads
+		resp.Diagnostics.AddError(
+			"Could not detect Woodpecker API",
+			"The server's version is needed to choose between the ID based "+
+				"and the legacy API. Set api_style (or WOODPECKER_API_STYLE) "+
+				"to id or legacy to skip the detection: "+err.Error(),
+		)
+
+		return
+
+	case p.config.APIStyle.IsNull() && !capabilities.version.known:
+		// development builds have no comparable version
+		idBased, err = probeIDBasedAPI(p.http, p.config.Server.ValueString())
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Could not detect Woodpecker API",
+				fmt.Sprintf("Server version %q can't be compared, and probing "+
+					"its API failed. Set api_style (or WOODPECKER_API_STYLE) "+
+					"to id or legacy to skip the detection: %s",
+					capabilities.rawVersion, err),
+			)
+
+			return
+		}
+
+	case p.config.APIStyle.IsNull():
+		idBased = capabilities.has(featureIDBasedAPI)
+	}
+
+	if idBased {
+		p.client = newIDClient(p.client, p.http, p.config.Server.ValueString())
+	} else {
+		p.client = newLegacyClient(p.client, p.http, p.config.Server.ValueString())
+	}
+
+	if p.config.ReadOnly.ValueBool() {
+		p.client = newReadOnlyClient(p.client)
+	}
+}
+
+// getSelf returns the user the provider is authenticated as. The lookup
+// is deferred until first needed so that configuring the provider does
+// not require a reachable Woodpecker server.
+func (p *woodpeckerProvider) getSelf() (*woodpecker.User, error) {
+	p.selfMu.Lock()
+	defer p.selfMu.Unlock()
+
+	if p.self != nil {
+		return p.self, nil
+	}
+
+	if p.client == nil {
+		return nil, errProviderUnconfigured
+	}
+
+	self, err := p.client.Self()
+
+	if err != nil {
+		return nil, err
+	}
+
+	p.self = self
+
+	return p.self, nil
+}
+
+// requireAdmin reports an error when the provider is not authenticated as
+// a Woodpecker admin. Nothing is checked until the provider is
+// configured.
+func (p *woodpeckerProvider) requireAdmin(resourceType string, diagnostics *diag.Diagnostics) {
+	if p.client == nil {
+		return
+	}
+
+	self, err := p.getSelf()
+
+	if err != nil {
+		addClientError(diagnostics, "Unable to login", err)
+		return
+	}
+
+	if !self.Admin {
+		diagnostics.AddError(
+			"Admin Privileges Required",
+			fmt.Sprintf("Managing %s requires a Woodpecker admin, but %s is not one.", resourceType, self.Login),
+		)
+	}
 }
 
 type providerConfig struct {
 	Server types.String `tfsdk:"server"`
 	Token  types.String `tfsdk:"token"`
 	Verify types.Bool   `tfsdk:"verify"`
+
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryMinWait types.String `tfsdk:"retry_min_wait"`
+	RetryMaxWait types.String `tfsdk:"retry_max_wait"`
+
+	ReadOnly types.Bool   `tfsdk:"read_only"`
+	APIStyle types.String `tfsdk:"api_style"`
+
+	TLS *providerTLSConfig `tfsdk:"tls"`
+}
+
+// isKnown reports whether every configured value is known.
+func (c providerConfig) isKnown() bool {
+	known := !c.Server.IsUnknown() &&
+		!c.Token.IsUnknown() &&
+		!c.Verify.IsUnknown() &&
+		!c.MaxRetries.IsUnknown() &&
+		!c.RetryMinWait.IsUnknown() &&
+		!c.RetryMaxWait.IsUnknown() &&
+		!c.ReadOnly.IsUnknown() &&
+		!c.APIStyle.IsUnknown()
+
+	if known && c.TLS != nil {
+		known = !c.TLS.InsecureSkipVerify.IsUnknown() &&
+			!c.TLS.CAFile.IsUnknown() &&
+			!c.TLS.CAPEM.IsUnknown() &&
+			!c.TLS.ClientCert.IsUnknown() &&
+			!c.TLS.ClientKey.IsUnknown()
+	}
+
+	return known
 }
 
 func (p *woodpeckerProvider) createProviderConfiguration(
@@ -119,6 +378,67 @@ func (p *woodpeckerProvider) createProviderConfiguration(
 		config.Verify = types.BoolValue(os.Getenv("WOODPECKER_VERIFY") != "0")
 	}
 
+	if config.MaxRetries.IsNull() {
+		config.MaxRetries = types.Int64Value(3)
+
+		if value := os.Getenv("WOODPECKER_MAX_RETRIES"); value != "" {
+			retries, err := strconv.ParseInt(value, 10, 64)
+
+			if err != nil {
+				resp.Diagnostics.AddError("Invalid WOODPECKER_MAX_RETRIES environment variable", err.Error())
+				return config
+			}
+
+			config.MaxRetries = types.Int64Value(retries)
+		}
+	}
+
+	if config.RetryMinWait.IsNull() {
+		config.RetryMinWait = types.StringValue("1s")
+
+		if value := os.Getenv("WOODPECKER_RETRY_MIN_WAIT"); value != "" {
+			config.RetryMinWait = types.StringValue(value)
+		}
+	}
+
+	if config.RetryMaxWait.IsNull() {
+		config.RetryMaxWait = types.StringValue("30s")
+
+		if value := os.Getenv("WOODPECKER_RETRY_MAX_WAIT"); value != "" {
+			config.RetryMaxWait = types.StringValue(value)
+		}
+	}
+
+	if config.ReadOnly.IsNull() {
+		config.ReadOnly = types.BoolValue(false)
+
+		if value := os.Getenv("WOODPECKER_READ_ONLY"); value != "" {
+			readOnly, err := strconv.ParseBool(value)
+
+			if err != nil {
+				resp.Diagnostics.AddError("Invalid WOODPECKER_READ_ONLY environment variable", err.Error())
+				return config
+			}
+
+			config.ReadOnly = types.BoolValue(readOnly)
+		}
+	}
+
+	if config.APIStyle.IsNull() {
+		switch value := os.Getenv("WOODPECKER_API_STYLE"); value {
+		case "":
+			// detected from the server
+		case "id", "legacy":
+			config.APIStyle = types.StringValue(value)
+		default:
+			resp.Diagnostics.AddError(
+				"Invalid WOODPECKER_API_STYLE environment variable",
+				fmt.Sprintf("Expected id or legacy, got %q", value),
+			)
+			return config
+		}
+	}
+
 	return config
 }
 
@@ -126,24 +446,31 @@ func (p *woodpeckerProvider) createClient(
 	ctx context.Context,
 	config providerConfig,
 	resp *provider.ConfigureResponse,
-) (woodpecker.Client, *woodpecker.User) {
+) (woodpecker.Client, *http.Client) {
+
+	transport, err := createTransport(config)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid provider configuration", err.Error())
+		return nil, nil
+	}
 
 	oauth_config := new(oauth2.Config)
 
+	// oauth2 builds its authenticating client on top of the HTTP client
+	// found in the context
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{
+		Transport: &errorTransport{base: transport},
+	})
+
 	authenticator := oauth_config.Client(ctx, &oauth2.Token{
 		AccessToken: config.Token.ValueString(),
 	})
 
+	// no request is made until the client is first used
 	client := woodpecker.NewClient(config.Server.ValueString(), authenticator)
 
-	self, err := client.Self()
-
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to login", err.Error())
-		return nil, nil
-	}
-
-	return client, self
+	return client, authenticator
 }
 
 func New() provider.Provider {
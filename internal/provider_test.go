@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
@@ -15,14 +16,64 @@ func NewProto6ProviderFactory() map[string]func() (tfprotov6.ProviderServer, err
 }
 
 func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
+	// Without a Woodpecker instance (see .ci/reset.sh), tests run against
+	// an in-process fake of the Woodpecker API.
 	if v := os.Getenv("WOODPECKER_SERVER"); v == "" {
-		t.Fatal("WOODPECKER_SERVER must be set for acceptance tests")
+		newFakeWoodpecker(t).use(t)
+		return
 	}
 
 	if v := os.Getenv("WOODPECKER_TOKEN"); v == "" {
 		t.Fatal("WOODPECKER_TOKEN must be set for acceptance tests")
 	}
 }
+
+// testAccFake runs the test against the in-process fake of the Woodpecker
+// API, for tests depending on behavior a real instance cannot be set up
+// for (e.g. running pipelines, which needs an agent).
+func testAccFake(t *testing.T) *fakeWoodpecker {
+	if v := os.Getenv("WOODPECKER_SERVER"); v != "" {
+		t.Skip("only runs against the fake Woodpecker API")
+	}
+
+	fake := newFakeWoodpecker(t)
+	fake.use(t)
+
+	return fake
+}
+
+func TestProviderConfig_isKnown(t *testing.T) {
+	config := providerConfig{
+		Server:       types.StringValue("https://woodpecker.example.com"),
+		Token:        types.StringValue("token"),
+		Verify:       types.BoolValue(true),
+		MaxRetries:   types.Int64Value(3),
+		RetryMinWait: types.StringValue("1s"),
+		RetryMaxWait: types.StringValue("30s"),
+		ReadOnly:     types.BoolValue(false),
+	}
+
+	if !config.isKnown() {
+		t.Error("expected configuration to be known")
+	}
+
+	config.Server = types.StringUnknown()
+
+	if config.isKnown() {
+		t.Error("expected configuration with unknown server to be unknown")
+	}
+
+	config.Server = types.StringValue("https://woodpecker.example.com")
+	config.ReadOnly = types.BoolUnknown()
+
+	if config.isKnown() {
+		t.Error("expected configuration with unknown read_only to be unknown")
+	}
+
+	config.ReadOnly = types.BoolValue(false)
+	config.TLS = &providerTLSConfig{CAPEM: types.StringUnknown()}
+
+	if config.isKnown() {
+		t.Error("expected configuration with unknown ca_pem to be unknown")
+	}
+}
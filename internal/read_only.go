@@ -0,0 +1,209 @@
+package internal
+
+import (
+	"errors"
+
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+// errReadOnly is returned instead of calling Woodpecker when a change is
+// attempted while the provider is read-only.
+var errReadOnly = errors.New("the provider is read-only (read_only or " +
+	"WOODPECKER_READ_ONLY), refusing to change Woodpecker")
+
+// readOnlyClient rejects every call that changes Woodpecker, so that
+// resources fail before sending a request. Everything else falls through
+// to the embedded client.
+type readOnlyClient struct {
+	woodpecker.Client
+}
+
+func newReadOnlyClient(client woodpecker.Client) *readOnlyClient {
+	return &readOnlyClient{Client: client}
+}
+
+// users
+
+func (c *readOnlyClient) UserPost(*woodpecker.User) (*woodpecker.User, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) UserPatch(*woodpecker.User) (*woodpecker.User, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) UserDel(string) error {
+	return errReadOnly
+}
+
+// repos
+
+func (c *readOnlyClient) RepoListOpts(sync, all bool) ([]*woodpecker.Repo, error) {
+	// syncing stores the repositories found in the forge
+	if sync {
+		return nil, errReadOnly
+	}
+
+	return c.Client.RepoListOpts(sync, all)
+}
+
+func (c *readOnlyClient) RepoPost(string, string) (*woodpecker.Repo, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) RepoPatch(string, string, *woodpecker.RepoPatch) (*woodpecker.Repo, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) RepoMove(string, string, string) error {
+	return errReadOnly
+}
+
+func (c *readOnlyClient) RepoChown(string, string) (*woodpecker.Repo, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) RepoRepair(string, string) error {
+	return errReadOnly
+}
+
+func (c *readOnlyClient) RepoDel(string, string) error {
+	return errReadOnly
+}
+
+func (c *readOnlyClient) RepoRemove(string, string) error {
+	return errReadOnly
+}
+
+// pipelines
+
+// PipelineListPage only reads, but isn't part of woodpecker.Client
+func (c *readOnlyClient) PipelineListPage(owner, name string, page int) ([]*woodpecker.Pipeline, error) {
+	return listPipelinePage(c.Client, owner, name, page)
+}
+
+func (c *readOnlyClient) PipelineCreate(string, string, *woodpecker.PipelineOptions) (*woodpecker.Pipeline, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) PipelineStart(string, string, int, map[string]string) (*woodpecker.Pipeline, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) PipelineStop(string, string, int, int) error {
+	return errReadOnly
+}
+
+func (c *readOnlyClient) PipelineApprove(string, string, int) (*woodpecker.Pipeline, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) PipelineDecline(string, string, int) (*woodpecker.Pipeline, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) PipelineKill(string, string, int) error {
+	return errReadOnly
+}
+
+func (c *readOnlyClient) Deploy(string, string, int, string, map[string]string) (*woodpecker.Pipeline, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) LogsPurge(string, string, int) error {
+	return errReadOnly
+}
+
+// registries
+
+func (c *readOnlyClient) RegistryCreate(string, string, *woodpecker.Registry) (*woodpecker.Registry, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) RegistryUpdate(string, string, *woodpecker.Registry) (*woodpecker.Registry, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) RegistryDelete(string, string, string) error {
+	return errReadOnly
+}
+
+// secrets
+
+func (c *readOnlyClient) SecretCreate(string, string, *woodpecker.Secret) (*woodpecker.Secret, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) SecretUpdate(string, string, *woodpecker.Secret) (*woodpecker.Secret, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) SecretDelete(string, string, string) error {
+	return errReadOnly
+}
+
+func (c *readOnlyClient) OrgSecretCreate(string, *woodpecker.Secret) (*woodpecker.Secret, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) OrgSecretUpdate(string, *woodpecker.Secret) (*woodpecker.Secret, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) OrgSecretDelete(string, string) error {
+	return errReadOnly
+}
+
+func (c *readOnlyClient) GlobalSecretCreate(*woodpecker.Secret) (*woodpecker.Secret, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) GlobalSecretUpdate(*woodpecker.Secret) (*woodpecker.Secret, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) GlobalSecretDelete(string) error {
+	return errReadOnly
+}
+
+// server
+
+func (c *readOnlyClient) QueuePause() error {
+	return errReadOnly
+}
+
+func (c *readOnlyClient) QueueResume() error {
+	return errReadOnly
+}
+
+func (c *readOnlyClient) SetLogLevel(*woodpecker.LogLevel) (*woodpecker.LogLevel, error) {
+	return nil, errReadOnly
+}
+
+// crons
+
+func (c *readOnlyClient) CronCreate(string, string, *woodpecker.Cron) (*woodpecker.Cron, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) CronUpdate(string, string, *woodpecker.Cron) (*woodpecker.Cron, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) CronDelete(string, string, int64) error {
+	return errReadOnly
+}
+
+// agents
+
+func (c *readOnlyClient) AgentCreate(*woodpecker.Agent) (*woodpecker.Agent, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) AgentUpdate(*woodpecker.Agent) (*woodpecker.Agent, error) {
+	return nil, errReadOnly
+}
+
+func (c *readOnlyClient) AgentDelete(int64) error {
+	return errReadOnly
+}
@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func TestReadOnlyClient(t *testing.T) {
+	// the embedded client is never reached
+	client := newReadOnlyClient(nil)
+
+	if _, err := client.SecretCreate("test_user", "test_repo", &woodpecker.Secret{Name: "token"}); !errors.Is(err, errReadOnly) {
+		t.Errorf("expected secret creation to be rejected, got: %v", err)
+	}
+
+	if err := client.RepoDel("test_user", "test_repo"); !errors.Is(err, errReadOnly) {
+		t.Errorf("expected repository deletion to be rejected, got: %v", err)
+	}
+
+	if err := client.RepoRemove("test_user", "test_repo"); !errors.Is(err, errReadOnly) {
+		t.Errorf("expected repository removal to be rejected, got: %v", err)
+	}
+
+	if _, err := client.RepoListOpts(true, false); !errors.Is(err, errReadOnly) {
+		t.Errorf("expected repository sync to be rejected, got: %v", err)
+	}
+}
+
+func TestAccProvider_readOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccFake(t)
+			t.Setenv("WOODPECKER_READ_ONLY", "true")
+		},
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			// data sources keep working
+			{
+				Config: `
+data "woodpecker_self" "self" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.woodpecker_self.self", "login", "test_user"),
+				),
+			},
+			{
+				Config: `
+data "woodpecker_self" "self" {}
+
+resource "woodpecker_repository" "test_repo" {
+	owner = data.woodpecker_self.self.login
+	name  = "test_repo"
+}
+`,
+				ExpectError: regexp.MustCompile("read-only"),
+			},
+		},
+	})
+}
@@ -0,0 +1,293 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func NewAgentResource() resource.Resource {
+	return &ResourceAgent{}
+}
+
+type ResourceAgent struct {
+	client       woodpecker.Client
+	capabilities serverCapabilities
+}
+
+func (r ResourceAgent) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agent"
+}
+
+func (r ResourceAgent) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Provides an agent resource. Managing agents requires
+		admin privileges. For more information see [Woodpecker CI's documentation](https://woodpecker-ci.org/docs/next/administration/agent-config)`,
+
+		Attributes: map[string]schema.Attribute{
+			// Required Attributes
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Agent name",
+			},
+
+			// Optional Attributes
+			"no_schedule": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether new pipelines should not be scheduled on the agent",
+			},
+			"capacity": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Number of workflows the agent can run in parallel",
+			},
+
+			// Computed Attributes
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Agent ID",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Token the agent authenticates with",
+			},
+			"owner_id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "ID of the user that created the agent",
+			},
+			"created": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Time the agent was created (Unix timestamp)",
+			},
+			"updated": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Time the agent was last updated (Unix timestamp)",
+			},
+			"last_contact": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Time the agent last contacted the server (Unix timestamp)",
+			},
+			"platform": schema.StringAttribute{
+				Computed:    true,
+				Description: "Platform reported by the agent (e.g. linux/amd64)",
+			},
+			"backend": schema.StringAttribute{
+				Computed:    true,
+				Description: "Backend reported by the agent (e.g. docker)",
+			},
+			"version": schema.StringAttribute{
+				Computed:    true,
+				Description: "Version reported by the agent",
+			},
+		},
+	}
+}
+
+func (r *ResourceAgent) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*woodpeckerProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *woodpeckerProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+	r.capabilities = p.capabilities
+}
+
+func (r ResourceAgent) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// unmarshall request config into resourceData
+	var resourceData Agent
+	diags := req.Config.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	agent, err := r.client.AgentCreate(prepareAgentPatch(resourceData))
+
+	if err != nil {
+		resp.Diagnostics.AddError("Could not create agent", err.Error())
+		return
+	}
+
+	WoodpeckerToAgent(*agent, &resourceData)
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceAgent) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// if we're deleting the resource, no need to delete and recreate it
+		return
+	}
+
+	r.capabilities.require(featureAgents, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		// if we're creating the resource, no need to delete and recreate it
+		return
+	}
+
+	if r.client == nil {
+		// provider is not configured yet, leave computed values unknown
+		return
+	}
+
+	var plan, state Agent
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Computed Attributes
+	plan.ID = state.ID
+	plan.Token = state.Token
+	plan.OwnerID = state.OwnerID
+	plan.Created = state.Created
+
+	// Optional Attributes
+	if plan.NoSchedule.IsUnknown() {
+		plan.NoSchedule = state.NoSchedule
+	}
+
+	if plan.Capacity.IsUnknown() {
+		plan.Capacity = state.Capacity
+	}
+
+	// updated changes with every modification, and the agent's heartbeat
+	// (last_contact, platform, backend, version) may have changed by the
+	// time the update returns them
+	if plan.Name.Equal(state.Name) && plan.NoSchedule.Equal(state.NoSchedule) && plan.Capacity.Equal(state.Capacity) {
+		plan.Updated = state.Updated
+		plan.LastContact = state.LastContact
+		plan.Platform = state.Platform
+		plan.Backend = state.Backend
+		plan.Version = state.Version
+	}
+
+	diags = resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceAgent) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		// provider is not configured yet, keep the prior state
+		return
+	}
+
+	// unmarshall request config into resourceData
+	var resourceData Agent
+	diags := req.State.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	agent, err := r.client.Agent(resourceData.ID.ValueInt64())
+
+	if isNotFound(err) {
+		// agent was removed outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Could not refresh agent", err)
+		return
+	}
+
+	WoodpeckerToAgent(*agent, &resourceData)
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceAgent) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan Agent
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state Agent
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	patch := prepareAgentPatch(plan)
+	patch.ID = state.ID.ValueInt64()
+
+	agent, err := r.client.AgentUpdate(patch)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Could not update agent", err.Error())
+		return
+	}
+
+	plan.Token = state.Token
+	WoodpeckerToAgent(*agent, &plan)
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceAgent) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state Agent
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.AgentDelete(state.ID.ValueInt64())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting agent", err.Error())
+		return
+	}
+
+	// Remove resource from state
+	resp.State.RemoveResource(ctx)
+}
+
+func (r ResourceAgent) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected format: agent_id. Got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceAgent_basic(t *testing.T) {
+	name := "woodpecker_agent.test_agent"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+
+			// Create and Read testing
+			{
+				Config: agentConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "test_agent"),
+					resource.TestCheckResourceAttr(name, "no_schedule", "false"),
+					resource.TestCheckResourceAttrSet(name, "token"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update/Read testing
+			{
+				Config: agentUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "test_agent_renamed"),
+					resource.TestCheckResourceAttr(name, "no_schedule", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceAgent_connected(t *testing.T) {
+	name := "woodpecker_agent.test_agent"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccFake(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+
+			// Create and Read testing
+			{
+				Config: agentConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "test_agent"),
+					resource.TestCheckResourceAttrSet(name, "last_contact"),
+				),
+			},
+			// The agent reports in while it's being updated
+			{
+				Config: agentUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "test_agent_renamed"),
+					resource.TestCheckResourceAttr(name, "version", "next-fake"),
+				),
+			},
+		},
+	})
+}
+
+var agentConfig = `
+resource "woodpecker_agent" "test_agent" {
+	name = "test_agent"
+}
+`
+
+var agentUpdatedConfig = `
+resource "woodpecker_agent" "test_agent" {
+	name        = "test_agent_renamed"
+	no_schedule = true
+}
+`
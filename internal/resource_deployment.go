@@ -0,0 +1,314 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func NewDeploymentResource() resource.Resource {
+	return &ResourceDeployment{}
+}
+
+type ResourceDeployment struct {
+	client woodpecker.Client
+}
+
+func (r ResourceDeployment) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployment"
+}
+
+func (r ResourceDeployment) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Deploys a pipeline by starting a deployment pipeline
+		from it. Destroying the resource does not affect the deployment, and a
+		deployment purged from the repository's history is kept in state. For more
+		information see [Woodpecker CI's documentation](https://woodpecker-ci.org/docs/usage/pipeline-syntax#event)`,
+
+		Attributes: map[string]schema.Attribute{
+			// Required Attributes
+			"repo_owner": schema.StringAttribute{
+				Required: true,
+				Description: "User or organization responsible for repository. " +
+					"Can only change when the repository was moved, the deployment is kept.",
+			},
+			"repo_name": schema.StringAttribute{
+				Required: true,
+				Description: "Repository name. Can only change when the " +
+					"repository was moved, the deployment is kept.",
+			},
+			"environment": schema.StringAttribute{
+				Required:    true,
+				Description: "Environment to deploy to (e.g. production)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Optional Attributes (exactly one of pipeline or branch)
+			"pipeline": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Number of the pipeline to deploy",
+			},
+			"branch": schema.StringAttribute{
+				Optional: true,
+				Description: "Deploy the last successful pipeline on this branch. " +
+					"The pipeline is looked up again whenever the deployment is replaced.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"params": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Parameters passed to the deployment pipeline",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Computed Attributes
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Deployment pipeline ID",
+			},
+			"number": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Deployment pipeline number within the repository",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "Deployment pipeline status (e.g. pending, running, success, failure)",
+			},
+		},
+	}
+}
+
+func (r ResourceDeployment) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		ValidateExactlyOneOf{attributes: []string{"pipeline", "branch"}},
+	}
+}
+
+func (r *ResourceDeployment) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*woodpeckerProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *woodpeckerProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r ResourceDeployment) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var resourceData Deployment
+	diags := req.Plan.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repoOwner := resourceData.RepoOwner.ValueString()
+	repoName := resourceData.RepoName.ValueString()
+
+	if resourceData.Pipeline.IsUnknown() {
+		pipeline, err := r.findLastSuccessfulPipeline(repoOwner, repoName, resourceData.Branch.ValueString())
+
+		if err != nil {
+			resp.Diagnostics.AddError("Could not find pipeline to deploy", err.Error())
+			return
+		}
+
+		resourceData.Pipeline = types.Int64Value(int64(pipeline.Number))
+	}
+
+	params := map[string]string{}
+
+	if !resourceData.Params.IsNull() {
+		diags = resourceData.Params.ElementsAs(ctx, &params, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	deployment, err := r.client.Deploy(
+		repoOwner,
+		repoName,
+		int(resourceData.Pipeline.ValueInt64()),
+		resourceData.Environment.ValueString(),
+		params,
+	)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Could not create deployment", err.Error())
+		return
+	}
+
+	WoodpeckerToDeployment(*deployment, &resourceData)
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceDeployment) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state, config Deployment
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	diags = req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// pipeline is computed when deploying a branch, so only a configured
+	// pipeline can require a new deployment
+	pipelineChanged := !config.Pipeline.IsNull() && !config.Pipeline.Equal(state.Pipeline)
+
+	if pipelineChanged {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("pipeline"))
+	}
+
+	replaced := pipelineChanged ||
+		!plan.Environment.Equal(state.Environment) ||
+		!plan.Branch.Equal(state.Branch) ||
+		!plan.Params.Equal(state.Params)
+
+	switch {
+	case !replaced:
+		// the deployment is kept (e.g. after the repository was moved)
+		plan.Pipeline = state.Pipeline
+	case !plan.Branch.IsNull():
+		// the branch's last successful pipeline is looked up again
+		plan.Pipeline = types.Int64Unknown()
+	}
+
+	diags = resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceDeployment) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		// provider is not configured yet, keep the prior state
+		return
+	}
+
+	var resourceData Deployment
+	diags := req.State.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repoOwner := resourceData.RepoOwner.ValueString()
+	repoName := resourceData.RepoName.ValueString()
+	number := int(resourceData.Number.ValueInt64())
+
+	deployment, err := r.client.Pipeline(repoOwner, repoName, number)
+
+	if isNotFound(err) {
+		// the deployment was started, even if it was purged from the
+		// repository's history since; removing it from state would
+		// deploy again
+		return
+	}
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Could not refresh deployment", err)
+		return
+	}
+
+	WoodpeckerToDeployment(*deployment, &resourceData)
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceDeployment) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// every other configurable attribute creates a new deployment when
+	// changed, so only the repository (after it was moved) changed
+	var plan, state Deployment
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repoOwner := plan.RepoOwner.ValueString()
+	repoName := plan.RepoName.ValueString()
+
+	// pipeline IDs are unique, so a moved repository still has it
+	deployment, err := r.client.Pipeline(repoOwner, repoName, int(state.Number.ValueInt64()))
+
+	if isNotFound(err) || (err == nil && deployment.ID != state.ID.ValueInt64()) {
+		addRepositoryChangedError(&resp.Diagnostics, "deployment", repoOwner, repoName)
+		return
+	}
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Could not refresh deployment", err)
+		return
+	}
+
+	WoodpeckerToDeployment(*deployment, &plan)
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceDeployment) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// deployments are kept as part of the repository's history
+	resp.State.RemoveResource(ctx)
+}
+
+// findLastSuccessfulPipeline returns the most recent successful pipeline
+// on branch, not counting deployments.
+func (r ResourceDeployment) findLastSuccessfulPipeline(repoOwner, repoName, branch string) (*woodpecker.Pipeline, error) {
+	// pipelines are listed newest first
+	for page := 1; ; page++ {
+		pipelines, err := listPipelinePage(r.client, repoOwner, repoName, page)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(pipelines) == 0 {
+			break
+		}
+
+		for _, pipeline := range pipelines {
+			if pipeline.Branch == branch && pipeline.Status == "success" && pipeline.Event != "deployment" {
+				return pipeline, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no successful pipeline found on branch %q of %s/%s", branch, repoOwner, repoName)
+}
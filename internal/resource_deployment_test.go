@@ -0,0 +1,193 @@
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func TestAccResourceDeployment_basic(t *testing.T) {
+	pollInterval := pipelinePollInterval
+	pipelinePollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { pipelinePollInterval = pollInterval })
+
+	name := "woodpecker_deployment.test_deployment"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccFake(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+
+			// Create and Read testing
+			{
+				Config: deploymentConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "environment", "staging"),
+					resource.TestCheckResourceAttr(name, "branch", "main"),
+					resource.TestCheckResourceAttr(name, "pipeline", "1"),
+					resource.TestCheckResourceAttr(name, "number", "2"),
+					resource.TestCheckResourceAttrSet(name, "status"),
+				),
+			},
+			// Changing the environment creates a new deployment
+			{
+				Config: deploymentUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "environment", "production"),
+					resource.TestCheckResourceAttr(name, "pipeline", "1"),
+					resource.TestCheckResourceAttr(name, "number", "3"),
+					resource.TestCheckResourceAttr(name, "params.VERSION", "1.0.0"),
+				),
+			},
+			// Switching from a pipeline to a branch deploys the branch's pipeline
+			{
+				Config: deploymentBranchConfig("release"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "branch", "release"),
+					resource.TestCheckResourceAttr(name, "pipeline", "4"),
+					resource.TestCheckResourceAttr(name, "number", "5"),
+				),
+			},
+			// Changing the branch looks up the new branch's pipeline
+			{
+				Config: deploymentBranchConfig("main"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "branch", "main"),
+					resource.TestCheckResourceAttr(name, "pipeline", "1"),
+					resource.TestCheckResourceAttr(name, "number", "6"),
+				),
+			},
+			// The source is either a pipeline or a branch
+			{
+				Config:      deploymentInvalidConfig,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+		},
+	})
+}
+
+var deploymentConfig = `
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+resource "woodpecker_pipeline_trigger" "test_pipeline" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	branch     = "main"
+	wait       = true
+}
+resource "woodpecker_deployment" "test_deployment" {
+	repo_owner  = woodpecker_repository.test_repo.owner
+	repo_name   = woodpecker_repository.test_repo.name
+	environment = "staging"
+	branch      = "main"
+
+	depends_on = [woodpecker_pipeline_trigger.test_pipeline]
+}
+`
+
+var deploymentUpdatedConfig = `
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+resource "woodpecker_pipeline_trigger" "test_pipeline" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	branch     = "main"
+	wait       = true
+}
+resource "woodpecker_deployment" "test_deployment" {
+	repo_owner  = woodpecker_repository.test_repo.owner
+	repo_name   = woodpecker_repository.test_repo.name
+	environment = "production"
+	pipeline    = woodpecker_pipeline_trigger.test_pipeline.number
+
+	params = {
+		VERSION = "1.0.0"
+	}
+}
+`
+
+func deploymentBranchConfig(branch string) string {
+	return fmt.Sprintf(`
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+resource "woodpecker_pipeline_trigger" "test_pipeline" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	branch     = "main"
+	wait       = true
+}
+resource "woodpecker_pipeline_trigger" "test_release_pipeline" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	branch     = "release"
+	wait       = true
+}
+resource "woodpecker_deployment" "test_deployment" {
+	repo_owner  = woodpecker_repository.test_repo.owner
+	repo_name   = woodpecker_repository.test_repo.name
+	environment = "production"
+	branch      = %q
+
+	depends_on = [
+		woodpecker_pipeline_trigger.test_pipeline,
+		woodpecker_pipeline_trigger.test_release_pipeline,
+	]
+}
+`, branch)
+}
+
+var deploymentInvalidConfig = `
+resource "woodpecker_deployment" "test_deployment" {
+	repo_owner  = "test_user"
+	repo_name   = "test_repo"
+	environment = "production"
+	pipeline    = 1
+	branch      = "main"
+}
+`
+
+func TestResourceDeployment_findLastSuccessfulPipeline(t *testing.T) {
+	fake := newFakeWoodpecker(t)
+	fake.pipelinesPerPage = 2
+
+	client := newIDClient(nil, fake.httpClient(t), fake.server.URL)
+
+	repo, err := client.RepoPost("test_user", "test_repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, branch := range []string{"release", "main", "main", "main"} {
+		if _, err := client.PipelineCreate("test_user", "test_repo", &woodpecker.PipelineOptions{Branch: branch}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	fake.locked(func() {
+		for _, pipeline := range fake.repos[repo.ID].pipelines {
+			pipeline.setStatus("success")
+		}
+	})
+
+	r := ResourceDeployment{client: client}
+
+	// the release pipeline is only listed on the second page
+	pipeline, err := r.findLastSuccessfulPipeline("test_user", "test_repo", "release")
+	if err != nil || pipeline.Number != 1 {
+		t.Fatalf("unexpected pipeline: %+v, %v", pipeline, err)
+	}
+
+	if _, err := r.findLastSuccessfulPipeline("test_user", "test_repo", "feature"); err == nil {
+		t.Fatal("expected an error for a branch without pipelines")
+	}
+}
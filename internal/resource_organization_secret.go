@@ -51,12 +51,20 @@ func (r ResourceOrganizationSecret) Schema(ctx context.Context, req resource.Sch
 				},
 			},
 			"value": schema.StringAttribute{
-				Required:    true,
-				Description: "Secret Value",
-				Sensitive:   true,
+				Required: true,
+				Description: "Secret Value. Like every configured value, it's " +
+					"stored in plain text in the Terraform state.",
+				Sensitive: true,
 			},
 
 			// Optional Attributes
+			"value_version": schema.Int64Attribute{
+				Optional: true,
+				Description: "Change to save the value again, e.g. after it was " +
+					"changed outside of Terraform. Woodpecker never returns " +
+					"secret values, so such changes aren't detected otherwise. " +
+					"The value is still stored in the Terraform state.",
+			},
 			"plugins_only": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
@@ -171,6 +179,11 @@ func (r ResourceOrganizationSecret) ModifyPlan(ctx context.Context, req resource
 		return
 	}
 
+	if r.client == nil {
+		// provider is not configured yet, leave computed values unknown
+		return
+	}
+
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -211,6 +224,11 @@ func (r ResourceOrganizationSecret) ModifyPlan(ctx context.Context, req resource
 }
 
 func (r ResourceOrganizationSecret) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		// provider is not configured yet, keep the prior state
+		return
+	}
+
 	// unmarshall request config into resourceData
 	var resourceData OrganizationSecret
 	diags := req.State.Get(ctx, &resourceData)
@@ -225,8 +243,14 @@ func (r ResourceOrganizationSecret) Read(ctx context.Context, req resource.ReadR
 
 	secret, err := r.client.OrgSecret(owner, secretName)
 
+	if isNotFound(err) {
+		// secret was removed outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
-		resp.Diagnostics.AddError(err.Error(), "")
+		addClientError(&resp.Diagnostics, "Could not refresh organization secret", err)
 		return
 	}
 
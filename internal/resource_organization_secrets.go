@@ -0,0 +1,274 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func NewOrganizationSecretsResource() resource.Resource {
+	return &ResourceOrganizationSecrets{}
+}
+
+type ResourceOrganizationSecrets struct {
+	client woodpecker.Client
+}
+
+func (r ResourceOrganizationSecrets) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_secrets"
+}
+
+func (r ResourceOrganizationSecrets) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages all secrets of an organization. Secrets that
+		aren't declared, including secrets added in the UI, are deleted. **Creating
+		the resource deletes the organization's existing secrets that aren't
+		declared.** Don't combine this resource with woodpecker_organization_secret
+		for the same organization. Destroying the resource deletes the
+		organization's secrets.`,
+
+		Attributes: map[string]schema.Attribute{
+			// Required Attributes
+			"owner": schema.StringAttribute{
+				Required: true,
+				Description: "Organization name. Changing it deletes the " +
+					"secrets of the previous organization.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"secrets": secretSetAttribute(),
+
+			// Computed Attributes
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Organization name",
+			},
+		},
+	}
+}
+
+func (r *ResourceOrganizationSecrets) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*woodpeckerProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *woodpeckerProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r ResourceOrganizationSecrets) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var resourceData OrganizationSecrets
+	diags := req.Plan.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, diags := secretSetEntries(ctx, resourceData.Secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// secrets that already exist are taken over
+	entries, diags := reconcileSecrets(ctx, r.secretSet(resourceData), planned, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resourceData.ID = resourceData.Owner
+	resourceData.Secrets, diags = secretSetValue(ctx, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceOrganizationSecrets) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state OrganizationSecrets
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, diags := secretSetEntries(ctx, plan.Secrets)
+	resp.Diagnostics.Append(diags...)
+	validateSecretSetNames(planned, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() || plan.Secrets.IsUnknown() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Owner.Equal(state.Owner) {
+		// the resource is replaced
+		return
+	}
+
+	prior, diags := secretSetEntries(ctx, state.Secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planSecretSet(planned, prior)
+
+	plan.ID = state.ID
+	plan.Secrets, diags = secretSetValue(ctx, planned)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceOrganizationSecrets) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		// provider is not configured yet, keep the prior state
+		return
+	}
+
+	var resourceData OrganizationSecrets
+	diags := req.State.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior, diags := secretSetEntries(ctx, resourceData.Secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set := r.secretSet(resourceData)
+	secrets, err := set.list()
+
+	if isNotFound(err) {
+		// organization was removed outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Could not refresh organization secrets", err)
+		return
+	}
+
+	entries, diags := refreshSecrets(ctx, set, secrets, prior)
+	resp.Diagnostics.Append(diags...)
+
+	resourceData.ID = resourceData.Owner
+	resourceData.Secrets, diags = secretSetValue(ctx, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceOrganizationSecrets) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state OrganizationSecrets
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, diags := secretSetEntries(ctx, plan.Secrets)
+	resp.Diagnostics.Append(diags...)
+	prior, diags := secretSetEntries(ctx, state.Secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries, diags := reconcileSecrets(ctx, r.secretSet(plan), planned, prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.Owner
+	plan.Secrets, diags = secretSetValue(ctx, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceOrganizationSecrets) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state OrganizationSecrets
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries, diags := secretSetEntries(ctx, state.Secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteSecrets(r.secretSet(state), entries, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remove resource from state
+	resp.State.RemoveResource(ctx)
+}
+
+func (r ResourceOrganizationSecrets) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("owner"), req.ID)...)
+}
+
+func (r ResourceOrganizationSecrets) secretSet(resourceData OrganizationSecrets) organizationSecretSet {
+	return organizationSecretSet{
+		client: r.client,
+		owner:  resourceData.Owner.ValueString(),
+	}
+}
@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func TestAccResourceOrganizationSecrets_basic(t *testing.T) {
+	var fake *fakeWoodpecker
+
+	name := "woodpecker_organization_secrets.test_org_secrets"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { fake = testAccFake(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: organizationSecretsConfig("test_org"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "test_org"),
+					resource.TestCheckResourceAttr(name, "owner", "test_org"),
+					resource.TestCheckResourceAttr(name, "secrets.%", "1"),
+					resource.TestCheckResourceAttr(name, "secrets.test_org_secret.events.0", "push"),
+				),
+			},
+			// secrets added in the UI are deleted
+			{
+				PreConfig: func() {
+					fake.locked(func() {
+						fake.orgSecrets[fake.orgs["test_org"]]["ui_secret"] = &woodpecker.Secret{
+							ID:     fake.newID(),
+							Name:   "ui_secret",
+							Value:  "ui_value",
+							Events: []string{"push"},
+						}
+					})
+				},
+				Config: organizationSecretsConfig("test_org"),
+				Check: func(*terraform.State) error {
+					var count int
+					fake.locked(func() { count = len(fake.orgSecrets[fake.orgs["test_org"]]) })
+
+					if count != 1 {
+						return fmt.Errorf("expected only the declared secret, got %d", count)
+					}
+
+					return nil
+				},
+			},
+			// Import testing
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateId:           "test_org",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secrets.test_org_secret.value"},
+			},
+			// switching organizations replaces the resource
+			{
+				Config: organizationSecretsConfig("test_user"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "test_user"),
+					func(*terraform.State) error {
+						var previous, current int
+						fake.locked(func() {
+							previous = len(fake.orgSecrets[fake.orgs["test_org"]])
+							current = len(fake.orgSecrets[fake.orgs["test_user"]])
+						})
+
+						if previous != 0 {
+							return fmt.Errorf("expected the previous organization's secrets to be deleted, got %d", previous)
+						}
+
+						if current != 1 {
+							return fmt.Errorf("expected the declared secret, got %d", current)
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func organizationSecretsConfig(owner string) string {
+	return fmt.Sprintf(`
+resource "woodpecker_organization_secrets" "test_org_secrets" {
+	owner = %q
+
+	secrets = {
+		test_org_secret = {
+			value  = "test_value"
+			events = ["push"]
+		}
+	}
+}
+`, owner)
+}
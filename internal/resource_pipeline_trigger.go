@@ -0,0 +1,388 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+// pipelinePollInterval is how often a triggered pipeline is refreshed
+// while waiting for it to finish.
+var pipelinePollInterval = 5 * time.Second
+
+// defaultPipelineWaitTimeout is how long a triggered pipeline is waited on
+// unless wait_timeout is set.
+const defaultPipelineWaitTimeout = time.Hour
+
+func NewPipelineTriggerResource() resource.Resource {
+	return &ResourcePipelineTrigger{}
+}
+
+type ResourcePipelineTrigger struct {
+	client woodpecker.Client
+}
+
+func (r ResourcePipelineTrigger) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_trigger"
+}
+
+func (r ResourcePipelineTrigger) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Starts a manual pipeline for a repository when created.
+		Destroying the resource does not affect the pipeline, and a pipeline
+		purged from the repository's history is kept in state. For more
+		information see [Woodpecker CI's documentation](https://woodpecker-ci.org/docs/usage/pipeline-syntax#event)`,
+
+		Attributes: map[string]schema.Attribute{
+			// Required Attributes
+			"repo_owner": schema.StringAttribute{
+				Required: true,
+				Description: "User or organization responsible for repository. " +
+					"Can only change when the repository was moved, the pipeline is kept.",
+			},
+			"repo_name": schema.StringAttribute{
+				Required: true,
+				Description: "Repository name. Can only change when the " +
+					"repository was moved, the pipeline is kept.",
+			},
+
+			// Optional Attributes
+			"branch": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Branch to run the pipeline on, defaults to the repository's default branch",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"variables": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Variables passed to the pipeline",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Arbitrary values that start a new pipeline when changed",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait": schema.BoolAttribute{
+				Optional: true,
+				Description: "Wait for the pipeline to finish and fail if it does " +
+					"not succeed. Pipelines awaiting approval are waited on until " +
+					"they are approved or wait_timeout passes.",
+			},
+			"wait_timeout": schema.StringAttribute{
+				Optional: true,
+				Description: "How long to wait for the pipeline to finish " +
+					"(e.g. 30m) before failing, defaults to 1h",
+				Validators: []validator.String{
+					ValidateDuration{},
+				},
+			},
+
+			// Computed Attributes
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Pipeline ID",
+			},
+			"number": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Pipeline number within the repository",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "Pipeline status (e.g. pending, running, success, failure)",
+			},
+			"link": schema.StringAttribute{
+				Computed:    true,
+				Description: "Link to the pipeline's commit in the forge",
+			},
+		},
+	}
+}
+
+func (r *ResourcePipelineTrigger) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*woodpeckerProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *woodpeckerProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r ResourcePipelineTrigger) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var resourceData PipelineTrigger
+	diags := req.Plan.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repoOwner := resourceData.RepoOwner.ValueString()
+	repoName := resourceData.RepoName.ValueString()
+
+	options, diags := preparePipelineOptions(ctx, resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if options.Branch == "" {
+		repo, err := r.client.Repo(repoOwner, repoName)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Could not look up repository's default branch", err.Error())
+			return
+		}
+
+		options.Branch = repo.Branch
+	}
+
+	pipeline, err := r.client.PipelineCreate(repoOwner, repoName, options)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Could not start pipeline", err.Error())
+		return
+	}
+
+	resourceData.Branch = types.StringValue(options.Branch)
+
+	if resourceData.Wait.ValueBool() {
+		pipeline, err = waitForPipelineTimeout(ctx, r.client, repoOwner, repoName, pipeline, resourceData.WaitTimeout.ValueString())
+	}
+
+	WoodpeckerToPipelineTrigger(*pipeline, &resourceData)
+
+	// the pipeline was started either way, record it so a failed pipeline
+	// is started again (as the resource is tainted) on the next apply
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Could not wait for pipeline to finish", err.Error())
+		return
+	}
+
+	if pipelineFailed(pipeline.Status) {
+		resp.Diagnostics.AddError(
+			"Pipeline did not succeed",
+			fmt.Sprintf("Pipeline #%d of %s/%s finished with status %q.", pipeline.Number, repoOwner, repoName, pipeline.Status),
+		)
+	}
+}
+
+func (r ResourcePipelineTrigger) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// if we're deleting the resource, no need to delete and recreate it
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		// if we're creating the resource, no need to delete and recreate it
+		return
+	}
+
+	var plan, state PipelineTrigger
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only wait and the repository (after it was moved) can change
+	// without starting a new pipeline
+	plan.ID = state.ID
+	plan.Number = state.Number
+
+	if plan.RepoOwner.Equal(state.RepoOwner) && plan.RepoName.Equal(state.RepoName) {
+		plan.Status = state.Status
+		plan.Link = state.Link
+	}
+
+	diags = resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourcePipelineTrigger) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		// provider is not configured yet, keep the prior state
+		return
+	}
+
+	var resourceData PipelineTrigger
+	diags := req.State.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repoOwner := resourceData.RepoOwner.ValueString()
+	repoName := resourceData.RepoName.ValueString()
+	number := int(resourceData.Number.ValueInt64())
+
+	pipeline, err := r.client.Pipeline(repoOwner, repoName, number)
+
+	if isNotFound(err) {
+		// the pipeline was started, even if it was purged from the
+		// repository's history since; removing it from state would start
+		// a new one
+		return
+	}
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Could not refresh pipeline", err)
+		return
+	}
+
+	WoodpeckerToPipelineTrigger(*pipeline, &resourceData)
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourcePipelineTrigger) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// every other attribute starts a new pipeline, so only wait or the
+	// repository changed
+	var plan, state PipelineTrigger
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.RepoOwner.Equal(state.RepoOwner) || !plan.RepoName.Equal(state.RepoName) {
+		repoOwner := plan.RepoOwner.ValueString()
+		repoName := plan.RepoName.ValueString()
+
+		// pipeline IDs are unique, so a moved repository still has it
+		pipeline, err := r.client.Pipeline(repoOwner, repoName, int(state.Number.ValueInt64()))
+
+		if isNotFound(err) || (err == nil && pipeline.ID != state.ID.ValueInt64()) {
+			addRepositoryChangedError(&resp.Diagnostics, "pipeline", repoOwner, repoName)
+			return
+		}
+
+		if err != nil {
+			addClientError(&resp.Diagnostics, "Could not refresh pipeline", err)
+			return
+		}
+
+		WoodpeckerToPipelineTrigger(*pipeline, &plan)
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourcePipelineTrigger) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// pipelines are kept as part of the repository's history
+	resp.State.RemoveResource(ctx)
+}
+
+// waitForPipeline polls pipeline until it reaches a terminal status.
+func waitForPipeline(ctx context.Context, client woodpecker.Client, owner, name string, pipeline *woodpecker.Pipeline) (*woodpecker.Pipeline, error) {
+	ticker := time.NewTicker(pipelinePollInterval)
+	defer ticker.Stop()
+
+	for !pipelineFinished(pipeline.Status) {
+		select {
+		case <-ctx.Done():
+			return pipeline, ctx.Err()
+		case <-ticker.C:
+		}
+
+		refreshed, err := client.Pipeline(owner, name, pipeline.Number)
+
+		if err != nil {
+			return pipeline, err
+		}
+
+		pipeline = refreshed
+	}
+
+	return pipeline, nil
+}
+
+// waitForPipelineTimeout waits for pipeline like waitForPipeline, failing
+// once timeout (a duration, defaulting to defaultPipelineWaitTimeout) has
+// passed.
+func waitForPipelineTimeout(ctx context.Context, client woodpecker.Client, owner, name string, pipeline *woodpecker.Pipeline, timeout string) (*woodpecker.Pipeline, error) {
+	wait := defaultPipelineWaitTimeout
+
+	if timeout != "" {
+		var err error
+
+		if wait, err = time.ParseDuration(timeout); err != nil {
+			return pipeline, err
+		}
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	pipeline, err := waitForPipeline(waitCtx, client, owner, name, pipeline)
+
+	if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+		if pipeline.Status == "blocked" {
+			return pipeline, fmt.Errorf("pipeline #%d was not approved within %s", pipeline.Number, wait)
+		}
+
+		return pipeline, fmt.Errorf("pipeline #%d did not finish within %s, its status is %q", pipeline.Number, wait, pipeline.Status)
+	}
+
+	return pipeline, err
+}
+
+// pipelineFinished reports whether a pipeline with status will not run
+// any further.
+func pipelineFinished(status string) bool {
+	switch status {
+	case "created", "pending", "running", "blocked":
+		return false
+	default:
+		return true
+	}
+}
+
+// pipelineFailed reports whether a pipeline with status finished without
+// succeeding.
+func pipelineFailed(status string) bool {
+	switch status {
+	case "failure", "killed", "error", "declined":
+		return true
+	default:
+		return false
+	}
+}
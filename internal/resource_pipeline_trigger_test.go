@@ -0,0 +1,206 @@
+package internal
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func TestAccResourcePipelineTrigger_basic(t *testing.T) {
+	name := "woodpecker_pipeline_trigger.test_pipeline"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+
+			// Create and Read testing
+			{
+				Config: pipelineTriggerConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "repo_owner", "test_user"),
+					resource.TestCheckResourceAttr(name, "repo_name", "test_repo"),
+					resource.TestCheckResourceAttr(name, "branch", "main"),
+					resource.TestCheckResourceAttr(name, "number", "1"),
+					resource.TestCheckResourceAttrSet(name, "status"),
+				),
+			},
+			// Changing triggers starts a new pipeline
+			{
+				Config: pipelineTriggerUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "number", "2"),
+					resource.TestCheckResourceAttr(name, "variables.DEPLOY_TO", "staging"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourcePipelineTrigger_wait(t *testing.T) {
+	pollInterval := pipelinePollInterval
+	pipelinePollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { pipelinePollInterval = pollInterval })
+
+	var fake *fakeWoodpecker
+
+	name := "woodpecker_pipeline_trigger.test_pipeline"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { fake = testAccFake(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+
+			// Successful pipelines are waited on
+			{
+				Config: pipelineTriggerWaitConfig("1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "number", "1"),
+					resource.TestCheckResourceAttr(name, "status", "success"),
+				),
+			},
+			// Failed pipelines fail the apply
+			{
+				PreConfig:   func() { fake.setPipelineResult("failure") },
+				Config:      pipelineTriggerWaitConfig("2"),
+				ExpectError: regexp.MustCompile("Pipeline did not succeed"),
+			},
+			// Pipelines awaiting approval are waited on until wait_timeout
+			{
+				PreConfig:   func() { fake.setPipelineResult("blocked") },
+				Config:      pipelineTriggerTimeoutConfig,
+				ExpectError: regexp.MustCompile("was not approved within 100ms"),
+			},
+		},
+	})
+}
+
+func TestAccResourcePipelineTrigger_purged(t *testing.T) {
+	var fake *fakeWoodpecker
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { fake = testAccFake(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+
+			// Create and Read testing
+			{
+				Config: pipelineTriggerConfig,
+			},
+			// Purging the pipeline from the history doesn't start a new one
+			{
+				PreConfig: func() { fake.purgePipelines("test_user", "test_repo") },
+				Config:    pipelineTriggerConfig,
+				PlanOnly:  true,
+			},
+		},
+	})
+}
+
+func TestWaitForPipelineTimeout(t *testing.T) {
+	pollInterval := pipelinePollInterval
+	pipelinePollInterval = time.Millisecond
+	t.Cleanup(func() { pipelinePollInterval = pollInterval })
+
+	fake := newFakeWoodpecker(t)
+	fake.pipelineResult = "blocked"
+
+	client := newIDClient(nil, fake.httpClient(t), fake.server.URL)
+
+	if _, err := client.RepoPost("test_user", "test_repo"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	pipeline, err := client.PipelineCreate("test_user", "test_repo", &woodpecker.PipelineOptions{Branch: "main"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	pipeline, err = waitForPipelineTimeout(context.Background(), client, "test_user", "test_repo", pipeline, "50ms")
+	if err == nil || err.Error() != "pipeline #1 was not approved within 50ms" || pipeline.Status != "blocked" {
+		t.Fatalf("unexpected result: %+v, %v", pipeline, err)
+	}
+}
+
+func TestPipelineFinished(t *testing.T) {
+	for status, finished := range map[string]bool{
+		"pending": false,
+		"running": false,
+		"blocked": false,
+		"success": true,
+		"failure": true,
+		"skipped": true,
+	} {
+		if pipelineFinished(status) != finished {
+			t.Errorf("expected pipelineFinished(%q) to be %t", status, finished)
+		}
+	}
+
+	if pipelineFailed("success") || pipelineFailed("skipped") || !pipelineFailed("killed") {
+		t.Error("unexpected result of pipelineFailed")
+	}
+}
+
+var pipelineTriggerConfig = `
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+resource "woodpecker_pipeline_trigger" "test_pipeline" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+}
+`
+
+var pipelineTriggerUpdatedConfig = `
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+resource "woodpecker_pipeline_trigger" "test_pipeline" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	branch     = "main"
+
+	variables = {
+		DEPLOY_TO = "staging"
+	}
+}
+`
+
+func pipelineTriggerWaitConfig(revision string) string {
+	return `
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+resource "woodpecker_pipeline_trigger" "test_pipeline" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	wait       = true
+
+	triggers = {
+		revision = "` + revision + `"
+	}
+}
+`
+}
+
+var pipelineTriggerTimeoutConfig = `
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+resource "woodpecker_pipeline_trigger" "test_pipeline" {
+	repo_owner   = woodpecker_repository.test_repo.owner
+	repo_name    = woodpecker_repository.test_repo.name
+	wait         = true
+	wait_timeout = "100ms"
+
+	triggers = {
+		revision = "3"
+	}
+}
+`
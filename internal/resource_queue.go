@@ -0,0 +1,242 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func NewQueueResource() resource.Resource {
+	return &ResourceQueue{}
+}
+
+type ResourceQueue struct {
+	p      *woodpeckerProvider
+	client woodpecker.Client
+}
+
+func (r ResourceQueue) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_queue"
+}
+
+func (r ResourceQueue) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Pauses or resumes Woodpecker's pipeline queue. Requires
+		admin privileges. Destroying the resource pauses or resumes the queue
+		as it was before.`,
+
+		Attributes: map[string]schema.Attribute{
+			// Required Attributes
+			"paused": schema.BoolAttribute{
+				Required:    true,
+				Description: "Whether the queue is paused. Paused queues don't hand out new workflows to agents.",
+			},
+
+			// Computed Attributes
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Always \"queue\"",
+			},
+			"pending": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of workflows waiting for an agent",
+			},
+			"waiting_on_deps": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of workflows waiting for other workflows to finish",
+			},
+			"running": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of running workflows",
+			},
+			"workers": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of workflows agents can run in parallel",
+			},
+			"previously_paused": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the queue was paused before, restored on destroy",
+			},
+		},
+	}
+}
+
+func (r *ResourceQueue) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*woodpeckerProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *woodpeckerProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.p = p
+	r.client = p.client
+}
+
+func (r ResourceQueue) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var resourceData Queue
+	diags := req.Plan.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous, err := r.client.QueueInfo()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Could not retrieve queue info", err.Error())
+		return
+	}
+
+	if err := r.setPaused(resourceData.Paused.ValueBool()); err != nil {
+		resp.Diagnostics.AddError("Could not update queue", err.Error())
+		return
+	}
+
+	info, err := r.client.QueueInfo()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Could not retrieve queue info", err.Error())
+		return
+	}
+
+	WoodpeckerToQueue(*info, &resourceData)
+	resourceData.PreviouslyPaused = types.BoolValue(previous.Paused)
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceQueue) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.p != nil {
+		r.p.requireAdmin("the queue", &resp.Diagnostics)
+	}
+
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	// the statistics are refreshed when applying, the previous state is
+	// only recorded when the resource is created
+	var plan, state Queue
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	plan.PreviouslyPaused = state.PreviouslyPaused
+
+	diags = resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceQueue) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		// provider is not configured yet, keep the prior state
+		return
+	}
+
+	var resourceData Queue
+	diags := req.State.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := r.client.QueueInfo()
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Could not refresh queue info", err)
+		return
+	}
+
+	WoodpeckerToQueue(*info, &resourceData)
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceQueue) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan Queue
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setPaused(plan.Paused.ValueBool()); err != nil {
+		resp.Diagnostics.AddError("Could not update queue", err.Error())
+		return
+	}
+
+	info, err := r.client.QueueInfo()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Could not retrieve queue info", err.Error())
+		return
+	}
+
+	WoodpeckerToQueue(*info, &plan)
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceQueue) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state Queue
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setPaused(state.PreviouslyPaused.ValueBool()); err != nil {
+		resp.Diagnostics.AddError("Error restoring queue", err.Error())
+		return
+	}
+
+	// Remove resource from state
+	resp.State.RemoveResource(ctx)
+}
+
+func (r ResourceQueue) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	info, err := r.client.QueueInfo()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Could not retrieve queue info", err.Error())
+		return
+	}
+
+	// without knowing the state before, destroying keeps the current one
+	var resourceData Queue
+	WoodpeckerToQueue(*info, &resourceData)
+	resourceData.PreviouslyPaused = resourceData.Paused
+
+	diags := resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceQueue) setPaused(paused bool) error {
+	if paused {
+		return r.client.QueuePause()
+	}
+
+	return r.client.QueueResume()
+}
@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccResourceQueue_basic(t *testing.T) {
+	name := "woodpecker_queue.test_queue"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+
+			// Create and Read testing
+			{
+				Config: queueConfig(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "paused", "true"),
+					resource.TestCheckResourceAttr(name, "previously_paused", "false"),
+					resource.TestCheckResourceAttrSet(name, "pending"),
+					resource.TestCheckResourceAttrSet(name, "running"),
+					resource.TestCheckResourceAttrSet(name, "workers"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     "queue",
+				ImportStateVerify: true,
+				// the state before can't be imported
+				ImportStateVerifyIgnore: []string{"previously_paused"},
+			},
+			// Update/Read testing
+			{
+				Config: queueConfig(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "paused", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceQueue_restoresPaused(t *testing.T) {
+	var fake *fakeWoodpecker
+
+	name := "woodpecker_queue.test_queue"
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			fake = testAccFake(t)
+			fake.setQueuePaused(true)
+		},
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		CheckDestroy: func(*terraform.State) error {
+			if !fake.isQueuePaused() {
+				return errors.New("expected the queue to be paused again")
+			}
+
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: queueConfig(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "paused", "false"),
+					resource.TestCheckResourceAttr(name, "previously_paused", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceQueue_requiresAdmin(t *testing.T) {
+	var fake *fakeWoodpecker
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { fake = testAccFake(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				PreConfig:   func() { fake.setAdmin("test_user", false) },
+				Config:      queueConfig(true),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Admin Privileges Required"),
+			},
+		},
+	})
+}
+
+func queueConfig(paused bool) string {
+	if paused {
+		return `
+resource "woodpecker_queue" "test_queue" {
+	paused = true
+}
+`
+	}
+
+	return `
+resource "woodpecker_queue" "test_queue" {
+	paused = false
+}
+`
+}
@@ -5,11 +5,11 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
 )
 
@@ -18,7 +18,9 @@ func NewRepositoryResource() resource.Resource {
 }
 
 type ResourceRepository struct {
-	client woodpecker.Client
+	p            *woodpeckerProvider
+	client       woodpecker.Client
+	capabilities serverCapabilities
 }
 
 func (r ResourceRepository) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -32,18 +34,14 @@ func (r ResourceRepository) Schema(ctx context.Context, req resource.SchemaReque
 		Attributes: map[string]schema.Attribute{
 			// Required Attributes
 			"owner": schema.StringAttribute{
-				Required:    true,
-				Description: "User or organization responsible for repository",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Required: true,
+				Description: "User or organization responsible for repository. " +
+					"Changing it moves the repository after it was transferred in the forge.",
 			},
 			"name": schema.StringAttribute{
-				Required:    true,
-				Description: "Repository name",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Required: true,
+				Description: "Repository name. Changing it moves the repository " +
+					"after it was renamed in the forge.",
 			},
 
 			// Optional Attributes
@@ -81,6 +79,38 @@ func (r ResourceRepository) Schema(ctx context.Context, req resource.SchemaReque
 					"folder. When empty, defaults to `.woodpecker/*.yml` -> " +
 					"`.woodpecker.yml` -> `.drone.yml`.",
 			},
+			"user_id": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "ID of the user whose forge access Woodpecker " +
+					"uses for the repository's webhooks and status updates. " +
+					"Woodpecker can only transfer repositories to the user " +
+					"making the request, so this must be the provider's own " +
+					"user. Conflicts with `owner_login`.",
+			},
+			"owner_login": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Login of the user whose forge access " +
+					"Woodpecker uses for the repository's webhooks and status " +
+					"updates. Must be the provider's own user, see `user_id`. " +
+					"Conflicts with `user_id`.",
+			},
+			"delete_mode": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "What destroying the resource does: " +
+					"`deactivate` (default) disables the repository but keeps " +
+					"its pipelines, secrets, and crons for when it is " +
+					"activated again, `remove` deletes the repository along " +
+					"with them, and `abandon` only removes it from the " +
+					"Terraform state.",
+				Validators: []validator.String{
+					ValidateStringInSlice{values: []string{"deactivate", "remove", "abandon"}},
+				},
+			},
+			"repair_trigger": schema.StringAttribute{
+				Optional: true,
+				Description: "Arbitrary value, changing it repairs the " +
+					"repository (e.g. recreates its webhooks in the forge).",
+			},
 
 			// Computed Attributes
 			"id": schema.Int64Attribute{
@@ -115,6 +145,12 @@ func (r ResourceRepository) Schema(ctx context.Context, req resource.SchemaReque
 	}
 }
 
+func (r ResourceRepository) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		ValidateAtMostOneOf{attributes: []string{"user_id", "owner_login"}},
+	}
+}
+
 func (r *ResourceRepository) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -132,7 +168,9 @@ func (r *ResourceRepository) Configure(_ context.Context, req resource.Configure
 		return
 	}
 
+	r.p = p
 	r.client = p.client
+	r.capabilities = p.capabilities
 }
 
 func (r ResourceRepository) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -151,14 +189,16 @@ func (r ResourceRepository) Create(ctx context.Context, req resource.CreateReque
 	// This operation is needed for woodpecker <= 0.15 to refresh the
 	// list of known repositories. This is not needed for newer versions
 	// of woodpecker.
-	_, err := r.client.RepoListOpts(true, false)
+	if !r.capabilities.has(featureActivateWithoutSync) {
+		_, err := r.client.RepoListOpts(true, false)
 
-	if err != nil {
-		resp.Diagnostics.AddError("Could not refresh list of repositories", err.Error())
-		return
+		if err != nil {
+			resp.Diagnostics.AddError("Could not refresh list of repositories", err.Error())
+			return
+		}
 	}
 
-	_, err = r.client.RepoPost(repoOwner, repoName)
+	_, err := r.client.RepoPost(repoOwner, repoName)
 
 	if err != nil {
 		resp.Diagnostics.AddError("Could not activate repository", err.Error())
@@ -188,19 +228,30 @@ func (r ResourceRepository) Create(ctx context.Context, req resource.CreateReque
 }
 
 func (r ResourceRepository) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
-	if req.State.Raw.IsNull() {
-		// if we're creating the resource, no need to delete and recreate it
+	if req.Plan.Raw.IsNull() {
+		// if we're deleting the resource, no need to delete and recreate it
 		return
 	}
 
-	if req.Plan.Raw.IsNull() {
-		// if we're deleting the resource, no need to delete and recreate it
+	if r.client == nil {
+		// provider is not configured yet, leave computed values unknown
 		return
 	}
 
 	var plan, state Repository
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.validateUser(plan, &resp.Diagnostics)
+
+	if req.State.Raw.IsNull() {
+		// if we're creating the resource, no need to delete and recreate it
+		return
+	}
+
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -208,14 +259,17 @@ func (r ResourceRepository) ModifyPlan(ctx context.Context, req resource.ModifyP
 	}
 
 	plan.ID = state.ID
-	plan.FullName = state.FullName
 	plan.Avatar = state.Avatar
-
-	plan.Link = state.Link
 	plan.Kind = state.Kind
-	plan.Clone = state.Clone
 	plan.Branch = state.Branch
 
+	// moved repositories get a new name and links
+	if plan.Owner.Equal(state.Owner) && plan.Name.Equal(state.Name) {
+		plan.FullName = state.FullName
+		plan.Link = state.Link
+		plan.Clone = state.Clone
+	}
+
 	if plan.Visibility.IsUnknown() {
 		plan.Visibility = state.Visibility
 	}
@@ -241,6 +295,11 @@ func (r ResourceRepository) ModifyPlan(ctx context.Context, req resource.ModifyP
 }
 
 func (r ResourceRepository) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		// provider is not configured yet, keep the prior state
+		return
+	}
+
 	// unmarshall request config into resourceData
 	var resourceData Repository
 	diags := req.State.Get(ctx, &resourceData)
@@ -255,8 +314,14 @@ func (r ResourceRepository) Read(ctx context.Context, req resource.ReadRequest,
 
 	repo, err := r.client.Repo(repoOwner, repoName)
 
+	if isNotFound(err) {
+		// repository was removed outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
-		resp.Diagnostics.AddError(err.Error(), "")
+		addClientError(&resp.Diagnostics, "Could not refresh repository", err)
 		return
 	}
 
@@ -285,6 +350,19 @@ func (r ResourceRepository) Update(ctx context.Context, req resource.UpdateReque
 	repoOwner := repoState.Owner.ValueString()
 	repoName := repoState.Name.ValueString()
 
+	// moving keeps the repository's ID, secrets, crons, and pipelines
+	if !repoPlan.Owner.Equal(repoState.Owner) || !repoPlan.Name.Equal(repoState.Name) {
+		repoOwner = repoPlan.Owner.ValueString()
+		repoName = repoPlan.Name.ValueString()
+
+		err := r.client.RepoMove(repoState.Owner.ValueString(), repoState.Name.ValueString(), repoOwner+"/"+repoName)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Could not move repository", err.Error())
+			return
+		}
+	}
+
 	patch := prepareRepositoryPatch(repoPlan)
 
 	repo, err := r.client.RepoPatch(repoOwner, repoName, patch)
@@ -294,6 +372,25 @@ func (r ResourceRepository) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	// the owner can't be read back, so only changes to it are applied
+	userChanged := !repoPlan.UserID.Equal(repoState.UserID) || !repoPlan.OwnerLogin.Equal(repoState.OwnerLogin)
+
+	if userChanged && (!repoPlan.UserID.IsNull() || !repoPlan.OwnerLogin.IsNull()) {
+		repo, err = r.client.RepoChown(repoOwner, repoName)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Could not transfer repository", err.Error())
+			return
+		}
+	}
+
+	if !repoPlan.RepairTrigger.IsNull() && !repoPlan.RepairTrigger.Equal(repoState.RepairTrigger) {
+		if err := r.client.RepoRepair(repoOwner, repoName); err != nil {
+			resp.Diagnostics.AddError("Could not repair repository", err.Error())
+			return
+		}
+	}
+
 	WoodpeckerToRepository(*repo, &repoPlan)
 
 	diags = resp.State.Set(ctx, &repoPlan)
@@ -312,7 +409,24 @@ func (r ResourceRepository) Delete(ctx context.Context, req resource.DeleteReque
 	repoOwner := repoState.Owner.ValueString()
 	repoName := repoState.Name.ValueString()
 
-	err := r.client.RepoDel(repoOwner, repoName)
+	var err error
+
+	switch repoState.DeleteMode.ValueString() {
+	case "abandon":
+		// leave the repository as it is in Woodpecker
+	case "remove":
+		remover, ok := r.client.(repoRemover)
+
+		if !ok {
+			resp.Diagnostics.AddError("Error deleting repository", "The Woodpecker client can't remove repositories.")
+			return
+		}
+
+		err = remover.RepoRemove(repoOwner, repoName)
+	default:
+		// woodpecker-go only deactivates repositories
+		err = r.client.RepoDel(repoOwner, repoName)
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting repository", err.Error())
@@ -323,6 +437,42 @@ func (r ResourceRepository) Delete(ctx context.Context, req resource.DeleteReque
 	resp.State.RemoveResource(ctx)
 }
 
+// validateUser reports an error when user_id or owner_login do not match
+// the provider's own user, the only user Woodpecker can transfer a
+// repository to.
+func (r ResourceRepository) validateUser(plan Repository, diagnostics *diag.Diagnostics) {
+	if r.p == nil || plan.UserID.IsUnknown() || plan.OwnerLogin.IsUnknown() {
+		return
+	}
+
+	if plan.UserID.IsNull() && plan.OwnerLogin.IsNull() {
+		return
+	}
+
+	self, err := r.p.getSelf()
+
+	if err != nil {
+		addClientError(diagnostics, "Unable to login", err)
+		return
+	}
+
+	if !plan.UserID.IsNull() && plan.UserID.ValueInt64() != self.ID {
+		diagnostics.AddAttributeError(
+			path.Root("user_id"),
+			"Invalid Repository User",
+			fmt.Sprintf("Woodpecker can only transfer repositories to the user the provider is authenticated as (%s, ID %d).", self.Login, self.ID),
+		)
+	}
+
+	if !plan.OwnerLogin.IsNull() && plan.OwnerLogin.ValueString() != self.Login {
+		diagnostics.AddAttributeError(
+			path.Root("owner_login"),
+			"Invalid Repository User",
+			fmt.Sprintf("Woodpecker can only transfer repositories to the user the provider is authenticated as (%s).", self.Login),
+		)
+	}
+}
+
 func (r ResourceRepository) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	idParts := strings.Split(req.ID, "/")
 
@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/robfig/cron"
 	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
 )
 
@@ -18,7 +20,8 @@ func NewRepositoryCronResource() resource.Resource {
 }
 
 type ResourceRepositoryCron struct {
-	client woodpecker.Client
+	client       woodpecker.Client
+	capabilities serverCapabilities
 }
 
 func (r ResourceRepositoryCron) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -33,29 +36,36 @@ func (r ResourceRepositoryCron) Schema(ctx context.Context, req resource.SchemaR
 		Attributes: map[string]schema.Attribute{
 			// Required Attributes
 			"repo_owner": schema.StringAttribute{
-				Required:    true,
-				Description: "User or organization responsible for repository",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Required: true,
+				Description: "User or organization responsible for repository. " +
+					"Can only change when the repository was moved, the cron is kept.",
 			},
 			"repo_name": schema.StringAttribute{
-				Required:    true,
-				Description: "Repository name",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Required: true,
+				Description: "Repository name. Can only change when the " +
+					"repository was moved, the cron is kept.",
 			},
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "Cron Name",
 			},
 			"schedule": schema.StringAttribute{
-				Required:    true,
-				Description: "Schedule (based on UTC)",
+				Required: true,
+				MarkdownDescription: "Schedule (based on UTC), e.g. `0 0 2 * * *` " +
+					"or `@daily`. See the [cron expression format](https://pkg.go.dev/github.com/robfig/cron#hdr-CRON_Expression_Format).",
+				Validators: []validator.String{
+					ValidateCronSchedule{},
+				},
 			},
 
 			// Optional Attributes
+			"branch": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Branch to run the pipeline on, defaults to the repository's default branch",
+			},
+
+			// Computed Attributes
 			"repo_id": schema.Int64Attribute{
 				Computed:    true,
 				Description: "",
@@ -65,15 +75,10 @@ func (r ResourceRepositoryCron) Schema(ctx context.Context, req resource.SchemaR
 				Description: "",
 			},
 			"next_exec": schema.Int64Attribute{
-				Computed:    true,
-				Description: "",
-			},
-			"branch": schema.StringAttribute{
-				Computed:    true,
-				Description: "",
+				Computed: true,
+				Description: "Next time the cron will run (Unix timestamp). " +
+					"Previewed during plan when the schedule changes.",
 			},
-
-			// Computed
 			"id": schema.Int64Attribute{
 				Computed:    true,
 				Description: "",
@@ -104,6 +109,7 @@ func (r *ResourceRepositoryCron) Configure(_ context.Context, req resource.Confi
 	}
 
 	r.client = p.client
+	r.capabilities = p.capabilities
 }
 
 func (r ResourceRepositoryCron) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -131,24 +137,50 @@ func (r ResourceRepositoryCron) Create(ctx context.Context, req resource.CreateR
 	resourceData.RepoName = types.StringValue(repoName)
 	WoodpeckerToRepositoryCron(*cron, &resourceData)
 
+	// keep the value previewed during plan, the server's value is read
+	// on the next refresh
+	diags = req.Plan.GetAttribute(ctx, path.Root("next_exec"), &resourceData.NextExec)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	diags = resp.State.Set(ctx, &resourceData)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r ResourceRepositoryCron) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
-	if req.State.Raw.IsNull() {
-		// if we're creating the resource, no need to delete and recreate it
+	if req.Plan.Raw.IsNull() {
+		// if we're deleting the resource, no need to delete and recreate it
 		return
 	}
 
-	if req.Plan.Raw.IsNull() {
-		// if we're deleting the resource, no need to delete and recreate it
+	r.capabilities.require(featureCrons, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	var plan, state RepositoryCron
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		// if we're creating the resource, preview when it will first run
+		plan.NextExec = nextCronExec(plan.Schedule)
+		diags = resp.Plan.Set(ctx, &plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	if r.client == nil {
+		// provider is not configured yet, leave computed values unknown
+		return
+	}
+
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -179,11 +211,22 @@ func (r ResourceRepositoryCron) ModifyPlan(ctx context.Context, req resource.Mod
 		plan.Schedule = state.Schedule
 	}
 
+	if plan.Schedule.Equal(state.Schedule) {
+		plan.NextExec = state.NextExec
+	} else {
+		plan.NextExec = nextCronExec(plan.Schedule)
+	}
+
 	diags = resp.Plan.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r ResourceRepositoryCron) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		// provider is not configured yet, keep the prior state
+		return
+	}
+
 	// unmarshall request config into resourceData
 	var resourceData RepositoryCron
 	diags := req.State.Get(ctx, &resourceData)
@@ -199,8 +242,14 @@ func (r ResourceRepositoryCron) Read(ctx context.Context, req resource.ReadReque
 
 	cron, err := r.client.CronGet(repoOwner, repoName, cronId)
 
+	if isNotFound(err) {
+		// cron was removed outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
-		resp.Diagnostics.AddError(err.Error(), "")
+		addClientError(&resp.Diagnostics, "Could not refresh repository cron", err)
 		return
 	}
 
@@ -225,8 +274,23 @@ func (r ResourceRepositoryCron) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	repoOwner := repoCronState.RepoOwner.ValueString()
-	repoName := repoCronState.RepoName.ValueString()
+	repoOwner := repoCronPlan.RepoOwner.ValueString()
+	repoName := repoCronPlan.RepoName.ValueString()
+
+	if !repoCronPlan.RepoOwner.Equal(repoCronState.RepoOwner) || !repoCronPlan.RepoName.Equal(repoCronState.RepoName) {
+		// cron IDs are unique, so a moved repository still has it
+		_, err := r.client.CronGet(repoOwner, repoName, repoCronState.ID.ValueInt64())
+
+		if isNotFound(err) {
+			addRepositoryChangedError(&resp.Diagnostics, "repository cron", repoOwner, repoName)
+			return
+		}
+
+		if err != nil {
+			addClientError(&resp.Diagnostics, "Could not refresh repository cron", err)
+			return
+		}
+	}
 
 	cron := prepareRepositoryCronPatch(repoCronPlan)
 
@@ -237,8 +301,14 @@ func (r ResourceRepositoryCron) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	nextExec := repoCronPlan.NextExec
 	WoodpeckerToRepositoryCron(*cron, &repoCronPlan)
 
+	if !nextExec.IsUnknown() {
+		// keep the value previewed during plan
+		repoCronPlan.NextExec = nextExec
+	}
+
 	diags = resp.State.Set(ctx, &repoCronPlan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -304,3 +374,20 @@ func (r ResourceRepositoryCron) ImportState(ctx context.Context, req resource.Im
 
 	resp.Diagnostics.AddError("Could not find cron with provided name", "")
 }
+
+// nextCronExec previews when Woodpecker will next run schedule. The value
+// is unknown when the schedule is.
+func nextCronExec(schedule types.String) types.Int64 {
+	if schedule.IsNull() || schedule.IsUnknown() {
+		return types.Int64Unknown()
+	}
+
+	parsed, err := cron.Parse(schedule.ValueString())
+
+	if err != nil {
+		// reported by the schedule's validator
+		return types.Int64Unknown()
+	}
+
+	return types.Int64Value(parsed.Next(time.Now().UTC()).Unix())
+}
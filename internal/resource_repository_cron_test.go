@@ -1,8 +1,11 @@
 package internal
 
 import (
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -40,6 +43,39 @@ func TestAccResourceRepositoryCron_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "schedule", "@daily"),
 				),
 			},
+			// Invalid schedules are rejected during plan
+			{
+				Config:      repositoryCronInvalidConfig,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Cron Schedule"),
+			},
+		},
+	})
+}
+
+// The test repository only has its default branch, so changing the branch
+// is only tested against the fake Woodpecker API.
+func TestAccResourceRepositoryCron_update(t *testing.T) {
+	name := "woodpecker_repository_cron.test_repo_cron"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccFake(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: repositoryCronConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "schedule", "@daily"),
+				),
+			},
+			{
+				Config: repositoryCronUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", "test_cron"),
+					resource.TestCheckResourceAttr(name, "schedule", "0 30 2 * * *"),
+					resource.TestCheckResourceAttr(name, "branch", "release/1.0"),
+					resource.TestCheckResourceAttrSet(name, "next_exec"),
+				),
+			},
 		},
 	})
 }
@@ -56,3 +92,50 @@ resource "woodpecker_repository_cron" "test_repo_cron" {
 	schedule = "@daily"
 }
 `
+
+var repositoryCronUpdatedConfig = `
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+resource "woodpecker_repository_cron" "test_repo_cron" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	name       = "test_cron"
+	schedule   = "0 30 2 * * *"
+	branch     = "release/1.0"
+}
+`
+
+var repositoryCronInvalidConfig = `
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+resource "woodpecker_repository_cron" "test_repo_cron" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	name       = "test_cron"
+	schedule   = "@dialy"
+}
+`
+
+func TestNextCronExec(t *testing.T) {
+	next := nextCronExec(types.StringValue("@hourly"))
+
+	if next.IsUnknown() {
+		t.Fatal("expected next execution to be known")
+	}
+
+	if wait := time.Until(time.Unix(next.ValueInt64(), 0)); wait <= 0 || wait > time.Hour {
+		t.Errorf("expected next execution within the hour, got %s", wait)
+	}
+
+	if !nextCronExec(types.StringValue("@dialy")).IsUnknown() {
+		t.Error("expected invalid schedule to have unknown next execution")
+	}
+
+	if !nextCronExec(types.StringUnknown()).IsUnknown() {
+		t.Error("expected unknown schedule to have unknown next execution")
+	}
+}
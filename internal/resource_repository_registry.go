@@ -36,18 +36,14 @@ func (r ResourceRepositoryRegistry) Schema(ctx context.Context, req resource.Sch
 		Attributes: map[string]schema.Attribute{
 			// Required Attributes
 			"repo_owner": schema.StringAttribute{
-				Required:    true,
-				Description: "User or organization responsible for repository",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Required: true,
+				Description: "User or organization responsible for repository. " +
+					"Can only change when the repository was moved, the registry is kept.",
 			},
 			"repo_name": schema.StringAttribute{
-				Required:    true,
-				Description: "Repository name",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Required: true,
+				Description: "Repository name. Can only change when the " +
+					"repository was moved, the registry is kept.",
 			},
 			"address": schema.StringAttribute{
 				Required:    true,
@@ -61,12 +57,20 @@ func (r ResourceRepositoryRegistry) Schema(ctx context.Context, req resource.Sch
 				Description: "Registry Username",
 			},
 			"password": schema.StringAttribute{
-				Required:    true,
-				Description: "Registry Password",
-				Sensitive:   true,
+				Required: true,
+				Description: "Registry Password. Like every configured value, it's " +
+					"stored in plain text in the Terraform state.",
+				Sensitive: true,
 			},
 
 			// Optional Attributes
+			"password_version": schema.Int64Attribute{
+				Optional: true,
+				Description: "Change to save the password again, e.g. after it " +
+					"was changed outside of Terraform. Woodpecker never returns " +
+					"passwords, so such changes aren't detected otherwise. " +
+					"The password is still stored in the Terraform state.",
+			},
 			"token": schema.StringAttribute{
 				Optional:    true,
 				Computed:    true,
@@ -165,6 +169,11 @@ func (r ResourceRepositoryRegistry) ModifyPlan(ctx context.Context, req resource
 		return
 	}
 
+	if r.client == nil {
+		// provider is not configured yet, leave computed values unknown
+		return
+	}
+
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -209,6 +218,11 @@ func (r ResourceRepositoryRegistry) ModifyPlan(ctx context.Context, req resource
 }
 
 func (r ResourceRepositoryRegistry) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		// provider is not configured yet, keep the prior state
+		return
+	}
+
 	// unmarshall request config into resourceData
 	var resourceData RepositoryRegistry
 	diags := req.State.Get(ctx, &resourceData)
@@ -224,8 +238,14 @@ func (r ResourceRepositoryRegistry) Read(ctx context.Context, req resource.ReadR
 
 	registry, err := r.client.Registry(repoOwner, repoName, address)
 
+	if isNotFound(err) {
+		// registry was removed outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
-		resp.Diagnostics.AddError(err.Error(), "")
+		addClientError(&resp.Diagnostics, "Could not refresh repository registry", err)
 		return
 	}
 
@@ -250,8 +270,23 @@ func (r ResourceRepositoryRegistry) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	repoOwner := state.RepoOwner.ValueString()
-	repoName := state.RepoName.ValueString()
+	repoOwner := plan.RepoOwner.ValueString()
+	repoName := plan.RepoName.ValueString()
+
+	if !plan.RepoOwner.Equal(state.RepoOwner) || !plan.RepoName.Equal(state.RepoName) {
+		// registry IDs are unique, so a moved repository still has it
+		existing, err := r.client.Registry(repoOwner, repoName, state.Address.ValueString())
+
+		if isNotFound(err) || (err == nil && existing.ID != state.ID.ValueInt64()) {
+			addRepositoryChangedError(&resp.Diagnostics, "repository registry", repoOwner, repoName)
+			return
+		}
+
+		if err != nil {
+			addClientError(&resp.Diagnostics, "Could not refresh repository registry", err)
+			return
+		}
+	}
 
 	registry, diags := prepareRepositoryRegistryPatch(ctx, plan)
 
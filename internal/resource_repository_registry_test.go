@@ -1,9 +1,11 @@
 package internal
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccResourceRepositoryRegistry_basic(t *testing.T) {
@@ -60,3 +62,51 @@ resource "woodpecker_repository_registry" "test_repo_registry" {
 	password   = "reg_test_pass"
 }
 `
+
+func TestAccResourceRepositoryRegistry_passwordVersion(t *testing.T) {
+	var fake *fakeWoodpecker
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { fake = testAccFake(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: repositoryRegistryVersionConfig(1),
+			},
+			{
+				PreConfig: func() {
+					fake.locked(func() { fake.activeRepo("test_user", "test_repo").registries["docker.io"].Password = "changed" })
+				},
+				Config: repositoryRegistryVersionConfig(2),
+				Check: func(*terraform.State) error {
+					var password string
+					fake.locked(func() { password = fake.activeRepo("test_user", "test_repo").registries["docker.io"].Password })
+
+					if password != "reg_test_pass" {
+						return fmt.Errorf("expected password to be saved again, got %q", password)
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func repositoryRegistryVersionConfig(version int) string {
+	return fmt.Sprintf(`
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+
+resource "woodpecker_repository_registry" "test_repo_registry" {
+	repo_owner       = woodpecker_repository.test_repo.owner
+	repo_name        = woodpecker_repository.test_repo.name
+	address          = "docker.io"
+	username         = "reg_test_user"
+	password         = "reg_test_pass"
+	password_version = %d
+}
+`, version)
+}
@@ -37,18 +37,14 @@ func (r ResourceRepositorySecret) Schema(ctx context.Context, req resource.Schem
 		Attributes: map[string]schema.Attribute{
 			// Required Attributes
 			"repo_owner": schema.StringAttribute{
-				Required:    true,
-				Description: "User or organization responsible for repository",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Required: true,
+				Description: "User or organization responsible for repository. " +
+					"Can only change when the repository was moved, the secret is kept.",
 			},
 			"repo_name": schema.StringAttribute{
-				Required:    true,
-				Description: "Repository name",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Required: true,
+				Description: "Repository name. Can only change when the " +
+					"repository was moved, the secret is kept.",
 			},
 			"name": schema.StringAttribute{
 				Required:    true,
@@ -58,9 +54,10 @@ func (r ResourceRepositorySecret) Schema(ctx context.Context, req resource.Schem
 				},
 			},
 			"value": schema.StringAttribute{
-				Required:    true,
-				Description: "Secret Value",
-				Sensitive:   true,
+				Required: true,
+				Description: "Secret Value. Like every configured value, it's " +
+					"stored in plain text in the Terraform state.",
+				Sensitive: true,
 			},
 			"events": schema.SetAttribute{
 				ElementType: types.StringType,
@@ -73,6 +70,13 @@ func (r ResourceRepositorySecret) Schema(ctx context.Context, req resource.Schem
 			},
 
 			// Optional Attributes
+			"value_version": schema.Int64Attribute{
+				Optional: true,
+				Description: "Change to save the value again, e.g. after it was " +
+					"changed outside of Terraform. Woodpecker never returns " +
+					"secret values, so such changes aren't detected otherwise. " +
+					"The value is still stored in the Terraform state.",
+			},
 			"plugins_only": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
@@ -179,6 +183,11 @@ func (r ResourceRepositorySecret) ModifyPlan(ctx context.Context, req resource.M
 		return
 	}
 
+	if r.client == nil {
+		// provider is not configured yet, leave computed values unknown
+		return
+	}
+
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -223,6 +232,11 @@ func (r ResourceRepositorySecret) ModifyPlan(ctx context.Context, req resource.M
 }
 
 func (r ResourceRepositorySecret) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		// provider is not configured yet, keep the prior state
+		return
+	}
+
 	// unmarshall request config into resourceData
 	var resourceData RepositorySecret
 	diags := req.State.Get(ctx, &resourceData)
@@ -238,8 +252,14 @@ func (r ResourceRepositorySecret) Read(ctx context.Context, req resource.ReadReq
 
 	secret, err := r.client.Secret(repoOwner, repoName, secretName)
 
+	if isNotFound(err) {
+		// secret was removed outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
-		resp.Diagnostics.AddError(err.Error(), "")
+		addClientError(&resp.Diagnostics, "Could not refresh repository secret", err)
 		return
 	}
 
@@ -264,8 +284,23 @@ func (r ResourceRepositorySecret) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
-	repoOwner := repoSecretState.RepoOwner.ValueString()
-	repoName := repoSecretState.RepoName.ValueString()
+	repoOwner := repoSecretPlan.RepoOwner.ValueString()
+	repoName := repoSecretPlan.RepoName.ValueString()
+
+	if !repoSecretPlan.RepoOwner.Equal(repoSecretState.RepoOwner) || !repoSecretPlan.RepoName.Equal(repoSecretState.RepoName) {
+		// secret IDs are unique, so a moved repository still has it
+		existing, err := r.client.Secret(repoOwner, repoName, repoSecretState.Name.ValueString())
+
+		if isNotFound(err) || (err == nil && existing.ID != repoSecretState.ID.ValueInt64()) {
+			addRepositoryChangedError(&resp.Diagnostics, "repository secret", repoOwner, repoName)
+			return
+		}
+
+		if err != nil {
+			addClientError(&resp.Diagnostics, "Could not refresh repository secret", err)
+			return
+		}
+	}
 
 	secret, diags := prepareRepositorySecretPatch(ctx, repoSecretPlan)
 
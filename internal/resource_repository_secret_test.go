@@ -1,9 +1,11 @@
 package internal
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccResourceRepositorySecret_basic(t *testing.T) {
@@ -60,3 +62,65 @@ resource "woodpecker_repository_secret" "test_repo_secret" {
 	events     = ["push"]
 }
 `
+
+func TestAccResourceRepositorySecret_valueVersion(t *testing.T) {
+	var fake *fakeWoodpecker
+
+	secretValue := func(want string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			var value string
+			fake.locked(func() { value = fake.activeRepo("test_user", "test_repo").secrets["test_secret"].Value })
+
+			if value != want {
+				return fmt.Errorf("expected value %q, got %q", want, value)
+			}
+
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { fake = testAccFake(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: repositorySecretVersionConfig(1),
+				Check:  secretValue("test_value"),
+			},
+			// a value changed outside of Terraform isn't noticed...
+			{
+				PreConfig: func() {
+					fake.locked(func() { fake.activeRepo("test_user", "test_repo").secrets["test_secret"].Value = "changed" })
+				},
+				Config: repositorySecretVersionConfig(1),
+				Check:  secretValue("changed"),
+			},
+			// ...until the version changes
+			{
+				Config: repositorySecretVersionConfig(2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("woodpecker_repository_secret.test_secret", "value_version", "2"),
+					secretValue("test_value"),
+				),
+			},
+		},
+	})
+}
+
+func repositorySecretVersionConfig(version int) string {
+	return fmt.Sprintf(`
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+
+resource "woodpecker_repository_secret" "test_secret" {
+	repo_owner    = woodpecker_repository.test_repo.owner
+	repo_name     = woodpecker_repository.test_repo.name
+	name          = "test_secret"
+	value         = "test_value"
+	value_version = %d
+	events        = ["push"]
+}
+`, version)
+}
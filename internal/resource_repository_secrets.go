@@ -0,0 +1,295 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func NewRepositorySecretsResource() resource.Resource {
+	return &ResourceRepositorySecrets{}
+}
+
+type ResourceRepositorySecrets struct {
+	client woodpecker.Client
+}
+
+func (r ResourceRepositorySecrets) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repository_secrets"
+}
+
+func (r ResourceRepositorySecrets) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages all secrets of a repository. Secrets that aren't
+		declared, including secrets added in the UI, are deleted. **Creating the
+		resource deletes the repository's existing secrets that aren't declared.**
+		Don't combine this resource with woodpecker_repository_secret for the same
+		repository. Destroying the resource deletes the repository's secrets.`,
+
+		Attributes: map[string]schema.Attribute{
+			// Required Attributes
+			"repo_owner": schema.StringAttribute{
+				Required: true,
+				Description: "User or organization responsible for repository. " +
+					"Changing it deletes the secrets of the previous repository.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"repo_name": schema.StringAttribute{
+				Required: true,
+				Description: "Repository name. Changing it deletes the secrets " +
+					"of the previous repository.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"secrets": secretSetAttribute(),
+
+			// Computed Attributes
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Full name of the repository (repo_owner/repo_name)",
+			},
+		},
+	}
+}
+
+func (r *ResourceRepositorySecrets) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*woodpeckerProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *woodpeckerProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r ResourceRepositorySecrets) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var resourceData RepositorySecrets
+	diags := req.Plan.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, diags := secretSetEntries(ctx, resourceData.Secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// secrets that already exist are taken over
+	entries, diags := reconcileSecrets(ctx, r.secretSet(resourceData), planned, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resourceData.ID = types.StringValue(resourceData.RepoOwner.ValueString() + "/" + resourceData.RepoName.ValueString())
+	resourceData.Secrets, diags = secretSetValue(ctx, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceRepositorySecrets) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state RepositorySecrets
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, diags := secretSetEntries(ctx, plan.Secrets)
+	resp.Diagnostics.Append(diags...)
+	validateSecretSetNames(planned, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() || plan.Secrets.IsUnknown() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.RepoOwner.Equal(state.RepoOwner) || !plan.RepoName.Equal(state.RepoName) {
+		// the resource is replaced
+		return
+	}
+
+	prior, diags := secretSetEntries(ctx, state.Secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planSecretSet(planned, prior)
+
+	plan.ID = state.ID
+	plan.Secrets, diags = secretSetValue(ctx, planned)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceRepositorySecrets) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		// provider is not configured yet, keep the prior state
+		return
+	}
+
+	var resourceData RepositorySecrets
+	diags := req.State.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior, diags := secretSetEntries(ctx, resourceData.Secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set := r.secretSet(resourceData)
+	secrets, err := set.list()
+
+	if isNotFound(err) {
+		// repository was removed outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Could not refresh repository secrets", err)
+		return
+	}
+
+	entries, diags := refreshSecrets(ctx, set, secrets, prior)
+	resp.Diagnostics.Append(diags...)
+
+	resourceData.ID = types.StringValue(resourceData.RepoOwner.ValueString() + "/" + resourceData.RepoName.ValueString())
+	resourceData.Secrets, diags = secretSetValue(ctx, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceRepositorySecrets) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state RepositorySecrets
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, diags := secretSetEntries(ctx, plan.Secrets)
+	resp.Diagnostics.Append(diags...)
+	prior, diags := secretSetEntries(ctx, state.Secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries, diags := reconcileSecrets(ctx, r.secretSet(plan), planned, prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(plan.RepoOwner.ValueString() + "/" + plan.RepoName.ValueString())
+	plan.Secrets, diags = secretSetValue(ctx, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceRepositorySecrets) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state RepositorySecrets
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries, diags := secretSetEntries(ctx, state.Secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteSecrets(r.secretSet(state), entries, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remove resource from state
+	resp.State.RemoveResource(ctx)
+}
+
+func (r ResourceRepositorySecrets) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, "/")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected format: repo_owner/repo_name. Got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_owner"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_name"), idParts[1])...)
+}
+
+func (r ResourceRepositorySecrets) secretSet(resourceData RepositorySecrets) repositorySecretSet {
+	return repositorySecretSet{
+		client:    r.client,
+		repoOwner: resourceData.RepoOwner.ValueString(),
+		repoName:  resourceData.RepoName.ValueString(),
+	}
+}
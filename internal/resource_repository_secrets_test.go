@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func TestAccResourceRepositorySecrets_basic(t *testing.T) {
+	var fake *fakeWoodpecker
+
+	name := "woodpecker_repository_secrets.test_secrets"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { fake = testAccFake(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: repositorySecretsConfig("push"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "test_user/test_repo"),
+					resource.TestCheckResourceAttr(name, "secrets.%", "2"),
+					resource.TestCheckResourceAttr(name, "secrets.deploy_key.value", "test_value"),
+					resource.TestCheckResourceAttr(name, "secrets.deploy_key.events.#", "1"),
+					resource.TestCheckResourceAttr(name, "secrets.docker_password.images.#", "1"),
+					resource.TestCheckResourceAttr(name, "secrets.docker_password.plugins_only", "true"),
+				),
+			},
+			// secrets added in the UI are deleted, changed ones updated
+			{
+				PreConfig: func() {
+					fake.locked(func() {
+						fake.activeRepo("test_user", "test_repo").secrets["ui_secret"] = &woodpecker.Secret{
+							ID:     fake.newID(),
+							Name:   "ui_secret",
+							Value:  "ui_value",
+							Events: []string{"pull_request"},
+						}
+					})
+				},
+				Config: repositorySecretsConfig("tag"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "secrets.%", "2"),
+					resource.TestCheckResourceAttr(name, "secrets.deploy_key.events.0", "tag"),
+					func(*terraform.State) error {
+						var uiSecret bool
+						var count int
+						fake.locked(func() {
+							secrets := fake.activeRepo("test_user", "test_repo").secrets
+							_, uiSecret = secrets["ui_secret"]
+							count = len(secrets)
+						})
+
+						if uiSecret || count != 2 {
+							return fmt.Errorf("expected only the declared secrets, got %d", count)
+						}
+
+						return nil
+					},
+				),
+			},
+			// Import testing
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     "test_user/test_repo",
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"secrets.deploy_key.value",
+					"secrets.docker_password.value",
+				},
+			},
+		},
+	})
+}
+
+func repositorySecretsConfig(event string) string {
+	return fmt.Sprintf(`
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = "test_repo"
+}
+
+resource "woodpecker_repository_secrets" "test_secrets" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+
+	secrets = {
+		deploy_key = {
+			value  = "test_value"
+			events = [%q]
+		}
+		docker_password = {
+			value        = "test_password"
+			events       = ["push"]
+			images       = ["plugins/docker"]
+			plugins_only = true
+		}
+	}
+}
+`, event)
+}
@@ -1,9 +1,12 @@
 package internal
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccResourceRepository_basic(t *testing.T) {
@@ -40,6 +43,208 @@ func TestAccResourceRepository_basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceRepository_chownRepair(t *testing.T) {
+	var fake *fakeWoodpecker
+
+	name := "woodpecker_repository.test_repo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { fake = testAccFake(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: repositoryConfig,
+			},
+			// a repository activated by someone else is transferred and
+			// its webhooks repaired
+			{
+				PreConfig: func() { fake.locked(func() { fake.activeRepo("test_user", "test_repo").userID = 0 }) },
+				Config:    repositoryOwnerConfig("test_user", "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "owner_login", "test_user"),
+					resource.TestCheckResourceAttr(name, "repair_trigger", "1"),
+					func(*terraform.State) error {
+						var userID, ownerID int64
+						var repairs int
+						fake.locked(func() {
+							repo := fake.activeRepo("test_user", "test_repo")
+							userID, repairs = repo.userID, repo.repairs
+							ownerID = fake.users["test_user"].ID
+						})
+
+						if userID != ownerID || repairs != 1 {
+							return fmt.Errorf("expected repository to be transferred and repaired once, got user %d and %d repairs", userID, repairs)
+						}
+
+						return nil
+					},
+				),
+			},
+			// unchanged values don't repeat either action
+			{
+				Config: repositoryOwnerConfig("test_user", "1"),
+				Check: func(*terraform.State) error {
+					var repairs int
+					fake.locked(func() { repairs = fake.activeRepo("test_user", "test_repo").repairs })
+
+					if repairs != 1 {
+						return fmt.Errorf("expected one repair, got %d", repairs)
+					}
+
+					return nil
+				},
+			},
+			{
+				Config:      repositoryOwnerConfig("someone_else", "1"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Repository User"),
+			},
+		},
+	})
+}
+
+func TestAccResourceRepository_move(t *testing.T) {
+	var fake *fakeWoodpecker
+	var repoID int64
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { fake = testAccFake(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: repositoryMoveConfig("test_repo"),
+				Check: func(*terraform.State) error {
+					fake.locked(func() { repoID = fake.activeRepo("test_user", "test_repo").repo.ID })
+					return nil
+				},
+			},
+			// renaming the repository in the forge moves it in place,
+			// along with the secret
+			{
+				PreConfig: func() {
+					fake.locked(func() { *fake.forgeRepos[0] = *fake.newForgeRepo("test_user", "renamed_repo") })
+				},
+				Config: repositoryMoveConfig("renamed_repo"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("woodpecker_repository.test_repo", "full_name", "test_user/renamed_repo"),
+					resource.TestCheckResourceAttr("woodpecker_repository_secret.test_secret", "repo_name", "renamed_repo"),
+					func(*terraform.State) error {
+						var moved bool
+						fake.locked(func() {
+							repo := fake.activeRepo("test_user", "renamed_repo")
+							moved = repo != nil && repo.repo.ID == repoID && repo.secrets["test_secret"] != nil
+						})
+
+						if !moved {
+							return fmt.Errorf("expected repository %d to be moved with its secret", repoID)
+						}
+
+						return nil
+					},
+				),
+			},
+			// pointing the secret at another repository does not move it
+			{
+				Config: repositoryMoveConfig("renamed_repo") + `
+resource "woodpecker_repository_secret" "other_secret" {
+	repo_owner = "test_user"
+	repo_name  = "renamed_repo"
+	name       = "other_secret"
+	value      = "other"
+	events     = ["push"]
+}
+`,
+			},
+			{
+				Config: repositoryMoveConfig("renamed_repo") + `
+resource "woodpecker_repository_secret" "other_secret" {
+	repo_owner = "test_user"
+	repo_name  = "unknown_repo"
+	name       = "other_secret"
+	value      = "other"
+	events     = ["push"]
+}
+`,
+				ExpectError: regexp.MustCompile("Repository Changed"),
+			},
+		},
+	})
+}
+
+func repositoryMoveConfig(name string) string {
+	return fmt.Sprintf(`
+resource "woodpecker_repository" "test_repo" {
+	owner = "test_user"
+	name  = %q
+}
+
+resource "woodpecker_repository_secret" "test_secret" {
+	repo_owner = woodpecker_repository.test_repo.owner
+	repo_name  = woodpecker_repository.test_repo.name
+	name       = "test_secret"
+	value      = "test"
+	events     = ["push"]
+}
+`, name)
+}
+
+func TestAccResourceRepository_deleteMode(t *testing.T) {
+	for mode, expected := range map[string]string{
+		"deactivate": "inactive",
+		"remove":     "removed",
+		"abandon":    "active",
+	} {
+		mode, expected := mode, expected
+
+		t.Run(mode, func(t *testing.T) {
+			var fake *fakeWoodpecker
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { fake = testAccFake(t) },
+				ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+resource "woodpecker_repository" "test_repo" {
+	owner       = "test_user"
+	name        = "test_repo"
+	delete_mode = %q
+}
+`, mode),
+					},
+				},
+				CheckDestroy: func(*terraform.State) error {
+					state := "removed"
+
+					fake.locked(func() {
+						if repo := fake.knownRepo("test_user", "test_repo"); repo != nil && repo.inactive {
+							state = "inactive"
+						} else if repo != nil {
+							state = "active"
+						}
+					})
+
+					if state != expected {
+						return fmt.Errorf("expected repository to be %s, got %s", expected, state)
+					}
+
+					return nil
+				},
+			})
+		})
+	}
+}
+
+func repositoryOwnerConfig(ownerLogin, repairTrigger string) string {
+	return fmt.Sprintf(`
+resource "woodpecker_repository" "test_repo" {
+	owner          = "test_user"
+	name           = "test_repo"
+	owner_login    = %q
+	repair_trigger = %q
+}
+`, ownerLogin, repairTrigger)
+}
+
 var repositoryConfig = `
 resource "woodpecker_repository" "test_repo" {
 	owner = "test_user"
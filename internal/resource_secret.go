@@ -22,7 +22,8 @@ func NewSecretResource() resource.Resource {
 }
 
 type ResourceSecret struct {
-	client woodpecker.Client
+	client       woodpecker.Client
+	capabilities serverCapabilities
 }
 
 func (r ResourceSecret) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -44,9 +45,10 @@ func (r ResourceSecret) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"value": schema.StringAttribute{
-				Required:    true,
-				Description: "Secret Value",
-				Sensitive:   true,
+				Required: true,
+				Description: "Secret Value. Like every configured value, it's " +
+					"stored in plain text in the Terraform state.",
+				Sensitive: true,
 			},
 			"events": schema.SetAttribute{
 				ElementType: types.StringType,
@@ -59,6 +61,13 @@ func (r ResourceSecret) Schema(ctx context.Context, req resource.SchemaRequest,
 			},
 
 			// Optional Attributes
+			"value_version": schema.Int64Attribute{
+				Optional: true,
+				Description: "Change to save the value again, e.g. after it was " +
+					"changed outside of Terraform. Woodpecker never returns " +
+					"secret values, so such changes aren't detected otherwise. " +
+					"The value is still stored in the Terraform state.",
+			},
 			"plugins_only": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
@@ -98,6 +107,7 @@ func (r *ResourceSecret) Configure(_ context.Context, req resource.ConfigureRequ
 	}
 
 	r.client = p.client
+	r.capabilities = p.capabilities
 }
 
 func (r ResourceSecret) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -140,6 +150,12 @@ func (r ResourceSecret) ModifyPlan(ctx context.Context, req resource.ModifyPlanR
 		return
 	}
 
+	r.capabilities.require(featureGlobalSecrets, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var plan, state Secret
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -160,6 +176,11 @@ func (r ResourceSecret) ModifyPlan(ctx context.Context, req resource.ModifyPlanR
 		return
 	}
 
+	if r.client == nil {
+		// provider is not configured yet, leave computed values unknown
+		return
+	}
+
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -196,6 +217,11 @@ func (r ResourceSecret) ModifyPlan(ctx context.Context, req resource.ModifyPlanR
 }
 
 func (r ResourceSecret) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		// provider is not configured yet, keep the prior state
+		return
+	}
+
 	// unmarshall request config into resourceData
 	var resourceData Secret
 	diags := req.State.Get(ctx, &resourceData)
@@ -209,8 +235,14 @@ func (r ResourceSecret) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	secret, err := r.client.GlobalSecret(secretName)
 
+	if isNotFound(err) {
+		// secret was removed outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
-		resp.Diagnostics.AddError(err.Error(), "")
+		addClientError(&resp.Diagnostics, "Could not refresh secret", err)
 		return
 	}
 
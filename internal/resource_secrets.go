@@ -0,0 +1,260 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func NewSecretsResource() resource.Resource {
+	return &ResourceSecrets{}
+}
+
+type ResourceSecrets struct {
+	client       woodpecker.Client
+	capabilities serverCapabilities
+}
+
+func (r ResourceSecrets) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secrets"
+}
+
+func (r ResourceSecrets) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages all global secrets. Secrets that aren't declared,
+		including secrets added in the UI, are deleted. **Creating the resource
+		deletes the existing global secrets that aren't declared.** Don't combine
+		this resource with woodpecker_secret. Destroying the resource deletes all
+		global secrets.`,
+
+		Attributes: map[string]schema.Attribute{
+			// Required Attributes
+			"secrets": secretSetAttribute(),
+
+			// Computed Attributes
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Always \"secrets\"",
+			},
+		},
+	}
+}
+
+func (r *ResourceSecrets) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*woodpeckerProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *woodpeckerProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+	r.capabilities = p.capabilities
+}
+
+func (r ResourceSecrets) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var resourceData Secrets
+	diags := req.Plan.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, diags := secretSetEntries(ctx, resourceData.Secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// secrets that already exist are taken over
+	entries, diags := reconcileSecrets(ctx, r.secretSet(), planned, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resourceData.ID = types.StringValue("secrets")
+	resourceData.Secrets, diags = secretSetValue(ctx, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceSecrets) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	r.capabilities.require(featureGlobalSecrets, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan, state Secrets
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, diags := secretSetEntries(ctx, plan.Secrets)
+	resp.Diagnostics.Append(diags...)
+	validateSecretSetNames(planned, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() || plan.Secrets.IsUnknown() {
+		return
+	}
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior, diags := secretSetEntries(ctx, state.Secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planSecretSet(planned, prior)
+
+	plan.ID = state.ID
+	plan.Secrets, diags = secretSetValue(ctx, planned)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceSecrets) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		// provider is not configured yet, keep the prior state
+		return
+	}
+
+	var resourceData Secrets
+	diags := req.State.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior, diags := secretSetEntries(ctx, resourceData.Secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set := r.secretSet()
+	secrets, err := set.list()
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Could not refresh global secrets", err)
+		return
+	}
+
+	entries, diags := refreshSecrets(ctx, set, secrets, prior)
+	resp.Diagnostics.Append(diags...)
+
+	resourceData.ID = types.StringValue("secrets")
+	resourceData.Secrets, diags = secretSetValue(ctx, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceSecrets) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state Secrets
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, diags := secretSetEntries(ctx, plan.Secrets)
+	resp.Diagnostics.Append(diags...)
+
+	prior, diags := secretSetEntries(ctx, state.Secrets)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries, diags := reconcileSecrets(ctx, r.secretSet(), planned, prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue("secrets")
+	plan.Secrets, diags = secretSetValue(ctx, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceSecrets) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state Secrets
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries, diags := secretSetEntries(ctx, state.Secrets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteSecrets(r.secretSet(), entries, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remove resource from state
+	resp.State.RemoveResource(ctx)
+}
+
+func (r ResourceSecrets) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// there is only one set of global secrets, the ID is ignored
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), "secrets")...)
+}
+
+func (r ResourceSecrets) secretSet() globalSecretSet {
+	return globalSecretSet{client: r.client}
+}
@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func TestAccResourceSecrets_basic(t *testing.T) {
+	var fake *fakeWoodpecker
+
+	name := "woodpecker_secrets.test_secrets"
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			fake = testAccFake(t)
+			fake.globalSecrets["existing_secret"] = &woodpecker.Secret{
+				ID:     fake.newID(),
+				Name:   "existing_secret",
+				Value:  "existing_value",
+				Events: []string{"push"},
+			}
+		},
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			// existing secrets are taken over or deleted on create
+			{
+				Config: secretsConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "secrets"),
+					resource.TestCheckResourceAttr(name, "secrets.%", "1"),
+					resource.TestCheckResourceAttr(name, "secrets.test_secret.value", "test_value"),
+					func(*terraform.State) error {
+						var existing bool
+						var count int
+						fake.locked(func() {
+							_, existing = fake.globalSecrets["existing_secret"]
+							count = len(fake.globalSecrets)
+						})
+
+						if existing || count != 1 {
+							return fmt.Errorf("expected only the declared secret, got %d", count)
+						}
+
+						return nil
+					},
+				),
+			},
+			// Import testing
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateId:           "secrets",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secrets.test_secret.value"},
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			var count int
+			fake.locked(func() { count = len(fake.globalSecrets) })
+
+			if count != 0 {
+				return fmt.Errorf("expected global secrets to be deleted, got %d", count)
+			}
+
+			return nil
+		},
+	})
+}
+
+const secretsConfig = `
+resource "woodpecker_secrets" "test_secrets" {
+	secrets = {
+		test_secret = {
+			value  = "test_value"
+			events = ["push"]
+		}
+	}
+}
+`
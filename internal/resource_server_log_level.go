@@ -0,0 +1,216 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+func NewServerLogLevelResource() resource.Resource {
+	return &ResourceServerLogLevel{}
+}
+
+type ResourceServerLogLevel struct {
+	p      *woodpeckerProvider
+	client woodpecker.Client
+}
+
+func (r ResourceServerLogLevel) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_log_level"
+}
+
+func (r ResourceServerLogLevel) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Sets the log level of the Woodpecker server at runtime.
+		Requires admin privileges. Destroying the resource restores the log level
+		the server had before.`,
+
+		Attributes: map[string]schema.Attribute{
+			// Required Attributes
+			"log_level": schema.StringAttribute{
+				Required:    true,
+				Description: "Log level (one of trace, debug, info, warn, error, fatal, panic, disabled)",
+				Validators: []validator.String{
+					ValidateStringInSlice{values: []string{"trace", "debug", "info", "warn", "error", "fatal", "panic", "disabled"}},
+				},
+			},
+
+			// Computed Attributes
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Always \"log_level\"",
+			},
+			"previous_log_level": schema.StringAttribute{
+				Computed:    true,
+				Description: "Log level the server had before, restored on destroy",
+			},
+		},
+	}
+}
+
+func (r *ResourceServerLogLevel) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*woodpeckerProvider)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *woodpeckerProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.p = p
+	r.client = p.client
+}
+
+func (r ResourceServerLogLevel) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var resourceData ServerLogLevel
+	diags := req.Plan.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous, err := r.client.LogLevel()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Could not retrieve log level", err.Error())
+		return
+	}
+
+	logLevel, err := r.client.SetLogLevel(&woodpecker.LogLevel{Level: resourceData.LogLevel.ValueString()})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Could not set log level", err.Error())
+		return
+	}
+
+	resourceData.ID = types.StringValue("log_level")
+	resourceData.LogLevel = types.StringValue(logLevel.Level)
+	resourceData.PreviousLogLevel = types.StringValue(previous.Level)
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceServerLogLevel) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.p != nil {
+		r.p.requireAdmin("the server log level", &resp.Diagnostics)
+	}
+
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	// the previous level is only recorded when the resource is created
+	var plan, state ServerLogLevel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	plan.PreviousLogLevel = state.PreviousLogLevel
+
+	diags = resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceServerLogLevel) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		// provider is not configured yet, keep the prior state
+		return
+	}
+
+	var resourceData ServerLogLevel
+	diags := req.State.Get(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	logLevel, err := r.client.LogLevel()
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Could not refresh log level", err)
+		return
+	}
+
+	resourceData.LogLevel = types.StringValue(logLevel.Level)
+
+	diags = resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceServerLogLevel) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ServerLogLevel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	logLevel, err := r.client.SetLogLevel(&woodpecker.LogLevel{Level: plan.LogLevel.ValueString()})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Could not set log level", err.Error())
+		return
+	}
+
+	plan.LogLevel = types.StringValue(logLevel.Level)
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r ResourceServerLogLevel) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ServerLogLevel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.SetLogLevel(&woodpecker.LogLevel{Level: state.PreviousLogLevel.ValueString()})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Error restoring log level", err.Error())
+		return
+	}
+
+	// Remove resource from state
+	resp.State.RemoveResource(ctx)
+}
+
+func (r ResourceServerLogLevel) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	logLevel, err := r.client.LogLevel()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Could not retrieve log level", err.Error())
+		return
+	}
+
+	// without knowing the level before, destroying keeps the current one
+	resourceData := ServerLogLevel{
+		ID:               types.StringValue("log_level"),
+		LogLevel:         types.StringValue(logLevel.Level),
+		PreviousLogLevel: types.StringValue(logLevel.Level),
+	}
+
+	diags := resp.State.Set(ctx, &resourceData)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceServerLogLevel_basic(t *testing.T) {
+	name := "woodpecker_server_log_level.test_log_level"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+
+			// Create and Read testing
+			{
+				Config: serverLogLevelConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "log_level", "debug"),
+					resource.TestCheckResourceAttr(name, "previous_log_level", "info"),
+				),
+			},
+			// Update/Read testing
+			{
+				Config: serverLogLevelUpdatedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "log_level", "trace"),
+					resource.TestCheckResourceAttr(name, "previous_log_level", "info"),
+				),
+			},
+		},
+	})
+}
+
+var serverLogLevelConfig = `
+resource "woodpecker_server_log_level" "test_log_level" {
+	log_level = "debug"
+}
+`
+
+var serverLogLevelUpdatedConfig = `
+resource "woodpecker_server_log_level" "test_log_level" {
+	log_level = "trace"
+}
+`
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -17,6 +18,7 @@ func NewUserResource() resource.Resource {
 }
 
 type ResourceUser struct {
+	p      *woodpeckerProvider
 	client woodpecker.Client
 }
 
@@ -49,20 +51,22 @@ func (r ResourceUser) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Computed:    true,
 				Description: "Whether user is active in the system",
 			},
-
-			// Computed Attributes
-			"id": schema.Int64Attribute{
-				Computed:    true,
-				Description: "User ID",
-			},
 			"admin": schema.BoolAttribute{
+				Optional:    true,
 				Computed:    true,
-				Description: "Whether user is a Woodpecker admin",
+				Description: "Whether user is a Woodpecker admin. The user the provider is authenticated as can't be demoted.",
 			},
 			"avatar": schema.StringAttribute{
+				Optional:    true,
 				Computed:    true,
 				Description: "Avatar URL for user",
 			},
+
+			// Computed Attributes
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "User ID",
+			},
 		},
 	}
 }
@@ -84,6 +88,7 @@ func (r *ResourceUser) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
+	r.p = p
 	r.client = p.client
 }
 
@@ -102,16 +107,25 @@ func (r ResourceUser) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
-	_, err := r.client.UserPost(patch)
+	user, err := r.client.UserPost(patch)
 	if err != nil {
 		resp.Diagnostics.AddError("Could not create user", err.Error())
 		return
 	}
 
-	user, err := r.client.UserPatch(patch)
-	if err != nil {
-		resp.Diagnostics.AddError("Could not create user", err.Error())
-		return
+	// new users are active and not admins, anything else needs a patch
+	if resourceData.Active.IsNull() {
+		patch.Active = user.Active
+	}
+
+	if user.Admin != patch.Admin || user.Active != patch.Active {
+		patch.ID = user.ID
+
+		user, err = r.client.UserPatch(patch)
+		if err != nil {
+			resp.Diagnostics.AddError("Could not create user", err.Error())
+			return
+		}
 	}
 
 	WoodpeckerToUser(ctx, *user, &resourceData)
@@ -126,27 +140,39 @@ func (r ResourceUser) ModifyPlan(ctx context.Context, req resource.ModifyPlanReq
 		return
 	}
 
-	if req.Plan.Raw.IsNull() {
-		// if we're deleting the resource, no need to delete and recreate it
+	if r.client == nil {
+		// provider is not configured yet, leave computed values unknown
 		return
 	}
 
-	var plan, state User
-	diags := req.Plan.Get(ctx, &plan)
+	var state User
+	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
-	diags = req.State.Get(ctx, &state)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		r.protectSelf(state.Login.ValueString(), "delete", &resp.Diagnostics)
+		return
+	}
+
+	var plan User
+	diags = req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if state.Admin.ValueBool() && !plan.Admin.IsUnknown() && !plan.Admin.ValueBool() {
+		r.protectSelf(state.Login.ValueString(), "demote", &resp.Diagnostics)
+	}
+
 	// Unchangeable Attributes
 	plan.Login = state.Login
 
 	// Computed Attributes
 	plan.ID = state.ID
-	plan.Admin = state.Admin
-	plan.Avatar = state.Avatar
 
 	// Optional Attributes
 	if plan.Email.IsUnknown() {
@@ -157,11 +183,24 @@ func (r ResourceUser) ModifyPlan(ctx context.Context, req resource.ModifyPlanReq
 		plan.Active = state.Active
 	}
 
+	if plan.Admin.IsUnknown() {
+		plan.Admin = state.Admin
+	}
+
+	if plan.Avatar.IsUnknown() {
+		plan.Avatar = state.Avatar
+	}
+
 	diags = resp.Plan.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r ResourceUser) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		// provider is not configured yet, keep the prior state
+		return
+	}
+
 	// unmarshall request config into resourceData
 	var resourceData User
 	diags := req.State.Get(ctx, &resourceData)
@@ -173,8 +212,14 @@ func (r ResourceUser) Read(ctx context.Context, req resource.ReadRequest, resp *
 	login := resourceData.Login.ValueString()
 	user, err := r.client.User(login)
 
+	if isNotFound(err) {
+		// user was removed outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
-		resp.Diagnostics.AddError(err.Error(), "")
+		addClientError(&resp.Diagnostics, "Could not refresh user", err)
 		return
 	}
 
@@ -239,6 +284,28 @@ func (r ResourceUser) Delete(ctx context.Context, req resource.DeleteRequest, re
 	resp.State.RemoveResource(ctx)
 }
 
+// protectSelf reports an error when login is the user the provider is
+// authenticated as, which would otherwise lock the provider out.
+func (r ResourceUser) protectSelf(login, action string, diagnostics *diag.Diagnostics) {
+	if r.p == nil {
+		return
+	}
+
+	self, err := r.p.getSelf()
+
+	if err != nil {
+		addClientError(diagnostics, "Unable to login", err)
+		return
+	}
+
+	if self.Login == login {
+		diagnostics.AddError(
+			"Cannot Modify Own User",
+			fmt.Sprintf("Refusing to %s %s, the user the provider is authenticated as.", action, login),
+		)
+	}
+}
+
 func (r ResourceUser) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("login"), req.ID)...)
 }
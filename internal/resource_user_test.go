@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -29,9 +31,43 @@ func TestAccResourceUser_basic(t *testing.T) {
 			},
 			// Update/Read testing
 			{
-				Config: userConfig,
+				Config: userAdminConfig(true),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "login", "test_user_2"),
+					resource.TestCheckResourceAttr(name, "admin", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceUser_protectSelf(t *testing.T) {
+	var fake *fakeWoodpecker
+
+	name := "woodpecker_user.test_user_2"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { fake = testAccFake(t) },
+		ProtoV6ProviderFactories: NewProto6ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: userAdminConfig(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "admin", "true"),
+					resource.TestCheckResourceAttr(name, "active", "true"),
+				),
+			},
+			// the provider's own user can't be demoted
+			{
+				PreConfig:   func() { fake.setSelf("test_user_2") },
+				Config:      userAdminConfig(false),
+				ExpectError: regexp.MustCompile("Cannot Modify Own User"),
+			},
+			// other users can
+			{
+				PreConfig: func() { fake.setSelf("test_user") },
+				Config:    userAdminConfig(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "admin", "false"),
 				),
 			},
 		},
@@ -43,3 +79,13 @@ resource "woodpecker_user" "test_user_2" {
 	login  = "test_user_2"
 }
 `
+
+func userAdminConfig(admin bool) string {
+	return fmt.Sprintf(`
+resource "woodpecker_user" "test_user_2" {
+	login  = "test_user_2"
+	admin  = %t
+	avatar = "https://example.com/avatar.png"
+}
+`, admin)
+}
@@ -0,0 +1,390 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+// secretSet is the complete set of secrets of a repository, an
+// organization, or the server, as managed by the woodpecker_*_secrets
+// resources.
+type secretSet interface {
+	list() ([]*woodpecker.Secret, error)
+	create(*woodpecker.Secret) (*woodpecker.Secret, error)
+	update(*woodpecker.Secret) (*woodpecker.Secret, error)
+	delete(name string) error
+
+	// prepare and convert translate between entries and the API using the
+	// functions of the matching single secret resource
+	prepare(ctx context.Context, name string, entry SecretSetEntry) (*woodpecker.Secret, diag.Diagnostics)
+	convert(ctx context.Context, wSecret woodpecker.Secret, entry *SecretSetEntry) diag.Diagnostics
+}
+
+var secretSetEntryType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"id":           types.Int64Type,
+	"value":        types.StringType,
+	"plugins_only": types.BoolType,
+	"images":       types.SetType{ElemType: types.StringType},
+	"events":       types.SetType{ElemType: types.StringType},
+}}
+
+// secretSetAttribute returns the map of secrets shared by the
+// woodpecker_*_secrets resources.
+func secretSetAttribute() schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		Required:    true,
+		Description: "Secrets by name. Secrets that aren't in this map are deleted.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				// Required Attributes
+				"value": schema.StringAttribute{
+					Required:    true,
+					Description: "Secret Value",
+					Sensitive:   true,
+				},
+				"events": schema.SetAttribute{
+					ElementType: types.StringType,
+					Required:    true,
+					Description: "One or more event types where secret is available (one of push, tag, pull_request, deployment, cron, manual)",
+					Validators: []validator.Set{
+						&ValidateSetInSlice{values: []string{"push", "tag", "pull_request", "deployment", "cron", "manual"}},
+					},
+				},
+
+				// Optional Attributes
+				"plugins_only": schema.BoolAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "Whether secret is only available for plugins",
+				},
+				"images": schema.SetAttribute{
+					ElementType: types.StringType,
+					Optional:    true,
+					Computed:    true,
+					Description: "List of images where this secret is available, leave empty to allow all images",
+				},
+
+				// Computed Attributes
+				"id": schema.Int64Attribute{
+					Computed:    true,
+					Description: "Secret ID",
+				},
+			},
+		},
+	}
+}
+
+func (e SecretSetEntry) equal(other SecretSetEntry) bool {
+	return e.Value.Equal(other.Value) &&
+		e.PluginsOnly.Equal(other.PluginsOnly) &&
+		e.Images.Equal(other.Images) &&
+		e.Events.Equal(other.Events)
+}
+
+// secretSetEntries decodes the secrets attribute, which is empty while
+// unknown.
+func secretSetEntries(ctx context.Context, secrets types.Map) (map[string]SecretSetEntry, diag.Diagnostics) {
+	entries := map[string]SecretSetEntry{}
+
+	if secrets.IsNull() || secrets.IsUnknown() {
+		return entries, nil
+	}
+
+	diags := secrets.ElementsAs(ctx, &entries, false)
+
+	return entries, diags
+}
+
+func secretSetValue(ctx context.Context, entries map[string]SecretSetEntry) (types.Map, diag.Diagnostics) {
+	return types.MapValueFrom(ctx, secretSetEntryType, entries)
+}
+
+// validateSecretSetNames rejects names the API can't address.
+func validateSecretSetNames(entries map[string]SecretSetEntry, diags *diag.Diagnostics) {
+	for name := range entries {
+		if name == "" || strings.Contains(name, "/") {
+			diags.AddError(
+				"Invalid Secret Name",
+				fmt.Sprintf("Secret names must not be empty or contain `/`, got %q", name),
+			)
+		}
+	}
+}
+
+// planSecretSet keeps the computed attributes of secrets that already
+// exist, so that only the secrets that change show up in the plan.
+func planSecretSet(plan, state map[string]SecretSetEntry) {
+	for name, entry := range plan {
+		prior, ok := state[name]
+
+		if !ok {
+			continue
+		}
+
+		entry.ID = prior.ID
+
+		if entry.PluginsOnly.IsUnknown() {
+			entry.PluginsOnly = prior.PluginsOnly
+		}
+
+		if entry.Images.IsUnknown() {
+			entry.Images = prior.Images
+		}
+
+		plan[name] = entry
+	}
+}
+
+// refreshSecrets converts every listed secret, keeping the values known
+// from prior. Secrets missing from prior (e.g. added in the UI) have an
+// empty value, so they show up in the next plan.
+func refreshSecrets(ctx context.Context, set secretSet, secrets []*woodpecker.Secret, prior map[string]SecretSetEntry) (map[string]SecretSetEntry, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	entries := map[string]SecretSetEntry{}
+
+	for _, wSecret := range secrets {
+		entry := prior[wSecret.Name]
+		diags.Append(set.convert(ctx, *wSecret, &entry)...)
+		entries[wSecret.Name] = entry
+	}
+
+	return entries, diags
+}
+
+// reconcileSecrets makes the secrets of set match planned: missing secrets
+// are created, secrets that differ from prior are updated, and secrets
+// that aren't planned are deleted.
+func reconcileSecrets(ctx context.Context, set secretSet, planned, prior map[string]SecretSetEntry) (map[string]SecretSetEntry, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	secrets, err := set.list()
+
+	if err != nil {
+		addClientError(&diags, "Could not list secrets", err)
+		return nil, diags
+	}
+
+	existing := map[string]*woodpecker.Secret{}
+
+	for _, wSecret := range secrets {
+		existing[wSecret.Name] = wSecret
+	}
+
+	names := make([]string, 0, len(planned))
+
+	for name := range planned {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	entries := map[string]SecretSetEntry{}
+
+	for _, name := range names {
+		entry := planned[name]
+		wSecret, found := existing[name]
+		priorEntry, managed := prior[name]
+
+		if !found || !managed || !entry.equal(priorEntry) {
+			patch, patchDiags := set.prepare(ctx, name, entry)
+			diags.Append(patchDiags...)
+			if diags.HasError() {
+				return nil, diags
+			}
+
+			if found {
+				wSecret, err = set.update(patch)
+			} else {
+				wSecret, err = set.create(patch)
+			}
+
+			if err != nil {
+				diags.AddError(fmt.Sprintf("Could not save secret %q", name), err.Error())
+				return nil, diags
+			}
+		}
+
+		diags.Append(set.convert(ctx, *wSecret, &entry)...)
+		entries[name] = entry
+	}
+
+	for _, wSecret := range secrets {
+		if _, ok := planned[wSecret.Name]; ok {
+			continue
+		}
+
+		if err := set.delete(wSecret.Name); err != nil && !isNotFound(err) {
+			diags.AddError(fmt.Sprintf("Could not delete secret %q", wSecret.Name), err.Error())
+			return nil, diags
+		}
+	}
+
+	return entries, diags
+}
+
+// deleteSecrets deletes the secrets in state, ignoring secrets that are
+// already gone.
+func deleteSecrets(set secretSet, state map[string]SecretSetEntry, diags *diag.Diagnostics) {
+	for name := range state {
+		if err := set.delete(name); err != nil && !isNotFound(err) {
+			diags.AddError(fmt.Sprintf("Error deleting secret %q", name), err.Error())
+		}
+	}
+}
+
+// repositorySecretSet is the set of secrets of a repository.
+type repositorySecretSet struct {
+	client    woodpecker.Client
+	repoOwner string
+	repoName  string
+}
+
+func (s repositorySecretSet) list() ([]*woodpecker.Secret, error) {
+	return s.client.SecretList(s.repoOwner, s.repoName)
+}
+
+func (s repositorySecretSet) create(secret *woodpecker.Secret) (*woodpecker.Secret, error) {
+	return s.client.SecretCreate(s.repoOwner, s.repoName, secret)
+}
+
+func (s repositorySecretSet) update(secret *woodpecker.Secret) (*woodpecker.Secret, error) {
+	return s.client.SecretUpdate(s.repoOwner, s.repoName, secret)
+}
+
+func (s repositorySecretSet) delete(name string) error {
+	return s.client.SecretDelete(s.repoOwner, s.repoName, name)
+}
+
+func (s repositorySecretSet) prepare(ctx context.Context, name string, entry SecretSetEntry) (*woodpecker.Secret, diag.Diagnostics) {
+	return prepareRepositorySecretPatch(ctx, RepositorySecret{
+		RepoOwner:   types.StringValue(s.repoOwner),
+		RepoName:    types.StringValue(s.repoName),
+		ID:          entry.ID,
+		Name:        types.StringValue(name),
+		Value:       entry.Value,
+		PluginsOnly: entry.PluginsOnly,
+		Images:      entry.Images,
+		Events:      entry.Events,
+	})
+}
+
+func (s repositorySecretSet) convert(ctx context.Context, wSecret woodpecker.Secret, entry *SecretSetEntry) diag.Diagnostics {
+	secret := RepositorySecret{Value: entry.Value}
+	diags := WoodpeckerToRepositorySecret(ctx, wSecret, &secret)
+
+	*entry = SecretSetEntry{
+		ID:          secret.ID,
+		Value:       secret.Value,
+		PluginsOnly: secret.PluginsOnly,
+		Images:      secret.Images,
+		Events:      secret.Events,
+	}
+
+	return diags
+}
+
+// organizationSecretSet is the set of secrets of an organization.
+type organizationSecretSet struct {
+	client woodpecker.Client
+	owner  string
+}
+
+func (s organizationSecretSet) list() ([]*woodpecker.Secret, error) {
+	return s.client.OrgSecretList(s.owner)
+}
+
+func (s organizationSecretSet) create(secret *woodpecker.Secret) (*woodpecker.Secret, error) {
+	return s.client.OrgSecretCreate(s.owner, secret)
+}
+
+func (s organizationSecretSet) update(secret *woodpecker.Secret) (*woodpecker.Secret, error) {
+	return s.client.OrgSecretUpdate(s.owner, secret)
+}
+
+func (s organizationSecretSet) delete(name string) error {
+	return s.client.OrgSecretDelete(s.owner, name)
+}
+
+func (s organizationSecretSet) prepare(ctx context.Context, name string, entry SecretSetEntry) (*woodpecker.Secret, diag.Diagnostics) {
+	return prepareOrganizationSecretPatch(ctx, OrganizationSecret{
+		Owner:       types.StringValue(s.owner),
+		ID:          entry.ID,
+		Name:        types.StringValue(name),
+		Value:       entry.Value,
+		PluginsOnly: entry.PluginsOnly,
+		Images:      entry.Images,
+		Events:      entry.Events,
+	})
+}
+
+func (s organizationSecretSet) convert(ctx context.Context, wSecret woodpecker.Secret, entry *SecretSetEntry) diag.Diagnostics {
+	secret := OrganizationSecret{Value: entry.Value}
+	diags := WoodpeckerToOrganizationSecret(ctx, wSecret, &secret)
+
+	*entry = SecretSetEntry{
+		ID:          secret.ID,
+		Value:       secret.Value,
+		PluginsOnly: secret.PluginsOnly,
+		Images:      secret.Images,
+		Events:      secret.Events,
+	}
+
+	return diags
+}
+
+// globalSecretSet is the set of global secrets.
+type globalSecretSet struct {
+	client woodpecker.Client
+}
+
+func (s globalSecretSet) list() ([]*woodpecker.Secret, error) {
+	return s.client.GlobalSecretList()
+}
+
+func (s globalSecretSet) create(secret *woodpecker.Secret) (*woodpecker.Secret, error) {
+	return s.client.GlobalSecretCreate(secret)
+}
+
+func (s globalSecretSet) update(secret *woodpecker.Secret) (*woodpecker.Secret, error) {
+	return s.client.GlobalSecretUpdate(secret)
+}
+
+func (s globalSecretSet) delete(name string) error {
+	return s.client.GlobalSecretDelete(name)
+}
+
+func (s globalSecretSet) prepare(ctx context.Context, name string, entry SecretSetEntry) (*woodpecker.Secret, diag.Diagnostics) {
+	return prepareSecretPatch(ctx, Secret{
+		ID:          entry.ID,
+		Name:        types.StringValue(name),
+		Value:       entry.Value,
+		PluginsOnly: entry.PluginsOnly,
+		Images:      entry.Images,
+		Events:      entry.Events,
+	})
+}
+
+func (s globalSecretSet) convert(ctx context.Context, wSecret woodpecker.Secret, entry *SecretSetEntry) diag.Diagnostics {
+	secret := Secret{Value: entry.Value}
+	diags := WoodpeckerToSecret(ctx, wSecret, &secret)
+
+	*entry = SecretSetEntry{
+		ID:          secret.ID,
+		Value:       secret.Value,
+		PluginsOnly: secret.PluginsOnly,
+		Images:      secret.Images,
+		Events:      secret.Events,
+	}
+
+	return diags
+}
@@ -0,0 +1,118 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/woodpecker-ci/woodpecker/woodpecker-go/woodpecker"
+)
+
+// memorySecretSet keeps secrets in a map, recording the changes made.
+type memorySecretSet struct {
+	repositorySecretSet
+	secrets map[string]*woodpecker.Secret
+	changes []string
+}
+
+func (s *memorySecretSet) list() ([]*woodpecker.Secret, error) {
+	list := []*woodpecker.Secret{}
+
+	for _, secret := range s.secrets {
+		list = append(list, secret)
+	}
+
+	return list, nil
+}
+
+func (s *memorySecretSet) create(secret *woodpecker.Secret) (*woodpecker.Secret, error) {
+	secret.ID = int64(len(s.changes) + 100)
+	s.secrets[secret.Name] = secret
+	s.changes = append(s.changes, "create "+secret.Name)
+	return secret, nil
+}
+
+func (s *memorySecretSet) update(secret *woodpecker.Secret) (*woodpecker.Secret, error) {
+	secret.ID = s.secrets[secret.Name].ID
+	s.secrets[secret.Name] = secret
+	s.changes = append(s.changes, "update "+secret.Name)
+	return secret, nil
+}
+
+func (s *memorySecretSet) delete(name string) error {
+	if _, ok := s.secrets[name]; !ok {
+		return &apiError{StatusCode: http.StatusNotFound}
+	}
+
+	delete(s.secrets, name)
+	s.changes = append(s.changes, "delete "+name)
+	return nil
+}
+
+func TestReconcileSecrets(t *testing.T) {
+	ctx := context.Background()
+
+	entry := func(id int64, value string) SecretSetEntry {
+		events, _ := types.SetValueFrom(ctx, types.StringType, []string{"push"})
+		images, _ := types.SetValueFrom(ctx, types.StringType, []string(nil))
+
+		return SecretSetEntry{
+			ID:          types.Int64Value(id),
+			Value:       types.StringValue(value),
+			PluginsOnly: types.BoolValue(false),
+			Images:      images,
+			Events:      events,
+		}
+	}
+
+	set := &memorySecretSet{secrets: map[string]*woodpecker.Secret{
+		"keep":   {ID: 1, Name: "keep", Events: []string{"push"}},
+		"change": {ID: 2, Name: "change", Events: []string{"push"}},
+		"ui":     {ID: 3, Name: "ui", Events: []string{"pull_request"}},
+	}}
+
+	prior := map[string]SecretSetEntry{
+		"keep":   entry(1, "kept"),
+		"change": entry(2, "old"),
+	}
+
+	planned := map[string]SecretSetEntry{
+		"keep":   entry(1, "kept"),
+		"change": entry(2, "new"),
+		"new":    {Value: types.StringValue("created"), Events: prior["keep"].Events, PluginsOnly: types.BoolUnknown(), Images: types.SetUnknown(types.StringType), ID: types.Int64Unknown()},
+	}
+
+	entries, diags := reconcileSecrets(ctx, set, planned, prior)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	want := []string{"update change", "create new", "delete ui"}
+
+	if len(set.changes) != len(want) {
+		t.Fatalf("got changes %v, want %v", set.changes, want)
+	}
+
+	for i := range want {
+		if set.changes[i] != want[i] {
+			t.Fatalf("got changes %v, want %v", set.changes, want)
+		}
+	}
+
+	if len(entries) != 3 || entries["new"].ID.IsUnknown() || entries["new"].Value.ValueString() != "created" {
+		t.Fatalf("unexpected entries: %v", entries)
+	}
+
+	if entries["change"].Value.ValueString() != "new" || entries["keep"].ID.ValueInt64() != 1 {
+		t.Fatalf("unexpected entries: %v", entries)
+	}
+
+	// destroying only deletes what is left
+	deleteSecrets(set, map[string]SecretSetEntry{"keep": {}, "ui": {}}, &diags)
+
+	if diags.HasError() || len(set.secrets) != 2 {
+		t.Fatalf("unexpected result: %v, %v", set.secrets, diags)
+	}
+}
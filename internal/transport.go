@@ -0,0 +1,296 @@
+package internal
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type providerTLSConfig struct {
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	CAFile             types.String `tfsdk:"ca_file"`
+	CAPEM              types.String `tfsdk:"ca_pem"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+}
+
+// createTransport prepares the transport used beneath the oauth2
+// authenticator when talking to Woodpecker.
+func createTransport(config providerConfig) (http.RoundTripper, error) {
+	tlsConfig, err := createTLSConfig(config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	minWait, err := time.ParseDuration(config.RetryMinWait.ValueString())
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid retry_min_wait: %w", err)
+	}
+
+	maxWait, err := time.ParseDuration(config.RetryMaxWait.ValueString())
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid retry_max_wait: %w", err)
+	}
+
+	if maxWait < minWait {
+		return nil, fmt.Errorf("retry_max_wait (%s) must not be less than retry_min_wait (%s)", maxWait, minWait)
+	}
+
+	if config.MaxRetries.ValueInt64() < 0 {
+		return nil, fmt.Errorf("max_retries must not be negative")
+	}
+
+	return &retryTransport{
+		base:       transport,
+		maxRetries: int(config.MaxRetries.ValueInt64()),
+		minWait:    minWait,
+		maxWait:    maxWait,
+	}, nil
+}
+
+func createTLSConfig(config providerConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: !config.Verify.ValueBool(),
+	}
+
+	if config.TLS == nil {
+		return tlsConfig, nil
+	}
+
+	if config.TLS.InsecureSkipVerify.ValueBool() {
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	caFile := config.TLS.CAFile.ValueString()
+	caPEM := config.TLS.CAPEM.ValueString()
+
+	if caFile != "" || caPEM != "" {
+		pool, err := x509.SystemCertPool()
+
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if caFile != "" {
+			contents, err := os.ReadFile(caFile)
+
+			if err != nil {
+				return nil, fmt.Errorf("could not read ca_file: %w", err)
+			}
+
+			if !pool.AppendCertsFromPEM(contents) {
+				return nil, fmt.Errorf("no certificates found in ca_file %s", caFile)
+			}
+		}
+
+		if caPEM != "" && !pool.AppendCertsFromPEM([]byte(caPEM)) {
+			return nil, fmt.Errorf("no certificates found in ca_pem")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	clientCert := config.TLS.ClientCert.ValueString()
+	clientKey := config.TLS.ClientKey.ValueString()
+
+	if clientCert == "" && clientKey == "" {
+		return tlsConfig, nil
+	}
+
+	if clientCert == "" || clientKey == "" {
+		return nil, fmt.Errorf("client_cert and client_key must be provided together")
+	}
+
+	certPEM, err := readPEM(clientCert)
+
+	if err != nil {
+		return nil, fmt.Errorf("could not read client_cert: %w", err)
+	}
+
+	keyPEM, err := readPEM(clientKey)
+
+	if err != nil {
+		return nil, fmt.Errorf("could not read client_key: %w", err)
+	}
+
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+
+	if err != nil {
+		return nil, fmt.Errorf("could not load client certificate: %w", err)
+	}
+
+	tlsConfig.Certificates = []tls.Certificate{certificate}
+
+	return tlsConfig, nil
+}
+
+// readPEM returns value as-is when it holds PEM encoded data, otherwise
+// value is treated as the path to a PEM encoded file.
+func readPEM(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+
+	return os.ReadFile(value)
+}
+
+// retryablePosts lists POST endpoints that can safely be sent more than
+// once.
+var retryablePosts = []*regexp.Regexp{
+	regexp.MustCompile(`/api/repos/.+/(repair|chown)$`),
+	regexp.MustCompile(`/api/queue/(pause|resume)$`),
+	regexp.MustCompile(`/api/log-level$`),
+}
+
+// retryTransport retries requests that failed with a transient error
+// (e.g. while Woodpecker is restarting), waiting exponentially longer
+// between each attempt.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isRetryableRequest(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+
+			if err != nil {
+				return nil, err
+			}
+
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+
+		if attempt >= t.maxRetries || !isRetryableResponse(resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+
+		if resp != nil {
+			// drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns how long to wait before the next attempt, honoring the
+// server's Retry-After header when present.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > t.maxWait {
+				return t.maxWait
+			}
+
+			return wait
+		}
+	}
+
+	wait := t.minWait
+
+	for i := 0; i < attempt && wait < t.maxWait; i++ {
+		wait *= 2
+	}
+
+	if wait > t.maxWait {
+		return t.maxWait
+	}
+
+	return wait
+}
+
+func isRetryableRequest(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// the body can't be replayed
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		for _, pattern := range retryablePosts {
+			if pattern.MatchString(req.URL.Path) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func isRetryableResponse(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header, which holds either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+
+		if wait < 0 {
+			wait = 0
+		}
+
+		return wait, true
+	}
+
+	return 0, false
+}
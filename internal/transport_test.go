@@ -0,0 +1,192 @@
+package internal
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testTransportConfig() providerConfig {
+	return providerConfig{
+		Verify:       types.BoolValue(true),
+		MaxRetries:   types.Int64Value(2),
+		RetryMinWait: types.StringValue("1ms"),
+		RetryMaxWait: types.StringValue("5ms"),
+	}
+}
+
+func TestCreateTransport_tls(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caPEM := string(pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	}))
+
+	tests := map[string]struct {
+		verify    bool
+		tls       *providerTLSConfig
+		expectErr bool
+	}{
+		"verify": {
+			verify:    true,
+			expectErr: true,
+		},
+		"verify disabled": {
+			verify: false,
+		},
+		"insecure_skip_verify": {
+			verify: true,
+			tls:    &providerTLSConfig{InsecureSkipVerify: types.BoolValue(true)},
+		},
+		"ca_pem": {
+			verify: true,
+			tls:    &providerTLSConfig{CAPEM: types.StringValue(caPEM)},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := testTransportConfig()
+			config.Verify = types.BoolValue(test.verify)
+			config.TLS = test.tls
+
+			transport, err := createTransport(config)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+
+			if test.expectErr {
+				if err == nil {
+					t.Fatal("expected certificate error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestCreateTransport_clientCertRequiresKey(t *testing.T) {
+	config := testTransportConfig()
+	config.TLS = &providerTLSConfig{ClientCert: types.StringValue("/path/to/cert.pem")}
+
+	_, err := createTransport(config)
+
+	if err == nil {
+		t.Fatal("expected error when client_key is missing")
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	var attempts int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		body, _ := io.ReadAll(r.Body)
+
+		if r.Method == http.MethodPut && string(body) != "payload" {
+			t.Errorf("expected body to be replayed, got: %q", body)
+		}
+
+		if attempts < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport, err := createTransport(testTransportConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	client := &http.Client{Transport: transport}
+
+	tests := map[string]struct {
+		method         string
+		path           string
+		expectStatus   int
+		expectAttempts int
+	}{
+		"idempotent request is retried": {
+			method:         http.MethodPut,
+			path:           "/api/repos/owner/name/secrets/test",
+			expectStatus:   http.StatusOK,
+			expectAttempts: 3,
+		},
+		"unsafe POST is not retried": {
+			method:         http.MethodPost,
+			path:           "/api/repos/owner/name/secrets",
+			expectStatus:   http.StatusServiceUnavailable,
+			expectAttempts: 1,
+		},
+		"safe POST is retried": {
+			method:         http.MethodPost,
+			path:           "/api/repos/owner/name/repair",
+			expectStatus:   http.StatusOK,
+			expectAttempts: 3,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			attempts = 0
+
+			req, _ := http.NewRequest(test.method, server.URL+test.path, strings.NewReader("payload"))
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != test.expectStatus {
+				t.Errorf("expected status %d, got %d", test.expectStatus, resp.StatusCode)
+			}
+
+			if attempts != test.expectAttempts {
+				t.Errorf("expected %d attempts, got %d", test.expectAttempts, attempts)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_backoff(t *testing.T) {
+	transport := &retryTransport{minWait: time.Second, maxWait: 5 * time.Second}
+
+	for attempt, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		if wait := transport.backoff(attempt, nil); wait != expected {
+			t.Errorf("attempt %d: expected %s, got %s", attempt, expected, wait)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	if wait := transport.backoff(0, resp); wait != 3*time.Second {
+		t.Errorf("expected Retry-After to be honored, got %s", wait)
+	}
+
+	resp.Header.Set("Retry-After", "120")
+	if wait := transport.backoff(0, resp); wait != 5*time.Second {
+		t.Errorf("expected Retry-After to be capped, got %s", wait)
+	}
+}
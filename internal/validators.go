@@ -3,11 +3,19 @@ package internal
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/robfig/cron"
 )
 
 type ValidateSetInSlice struct {
@@ -69,3 +77,218 @@ func (r ValidateSetInSlice) validateAttr(attr types.String, req validator.SetReq
 		fmt.Sprintf("%s is not supported (expected: %s)", attr, strings.Join(r.values, ", ")),
 	)
 }
+
+type ValidateStringInSlice struct {
+	values []string
+}
+
+func (r ValidateStringInSlice) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(r.values, ", "))
+}
+
+func (r ValidateStringInSlice) MarkdownDescription(ctx context.Context) string {
+	return r.Description(ctx)
+}
+
+func (r ValidateStringInSlice) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	str := req.ConfigValue.ValueString()
+
+	for _, value := range r.values {
+		if value == str {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Value",
+		fmt.Sprintf("%s is not supported (expected: %s)", req.ConfigValue, strings.Join(r.values, ", ")),
+	)
+}
+
+type ValidateRegex struct{}
+
+func (r ValidateRegex) Description(ctx context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (r ValidateRegex) MarkdownDescription(ctx context.Context) string {
+	return r.Description(ctx)
+}
+
+func (r ValidateRegex) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Regular Expression", err.Error())
+	}
+}
+
+// ValidateDuration ensures a value can be parsed as a duration (e.g. 30m).
+type ValidateDuration struct{}
+
+func (r ValidateDuration) Description(ctx context.Context) string {
+	return "value must be a duration (e.g. \"90s\" or \"1h30m\")"
+}
+
+func (r ValidateDuration) MarkdownDescription(ctx context.Context) string {
+	return r.Description(ctx)
+}
+
+func (r ValidateDuration) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Duration", err.Error())
+	}
+}
+
+// ValidateInt64AtLeast ensures a value is at least min.
+type ValidateInt64AtLeast struct {
+	min int64
+}
+
+func (r ValidateInt64AtLeast) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be at least %d", r.min)
+}
+
+func (r ValidateInt64AtLeast) MarkdownDescription(ctx context.Context) string {
+	return r.Description(ctx)
+}
+
+func (r ValidateInt64AtLeast) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if req.ConfigValue.ValueInt64() < r.min {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Value",
+			fmt.Sprintf("%s is less than %d", req.ConfigValue, r.min),
+		)
+	}
+}
+
+// ValidateExactlyOneOf ensures exactly one of the given root attributes is
+// configured on a data source or resource.
+type ValidateExactlyOneOf struct {
+	attributes []string
+}
+
+func (r ValidateExactlyOneOf) Description(ctx context.Context) string {
+	return fmt.Sprintf("exactly one of %s must be configured", strings.Join(r.attributes, ", "))
+}
+
+func (r ValidateExactlyOneOf) MarkdownDescription(ctx context.Context) string {
+	return r.Description(ctx)
+}
+
+func (r ValidateExactlyOneOf) ValidateDataSource(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	r.validate(ctx, req.Config, &resp.Diagnostics)
+}
+
+func (r ValidateExactlyOneOf) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	r.validate(ctx, req.Config, &resp.Diagnostics)
+}
+
+func (r ValidateExactlyOneOf) validate(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	configured, known := countConfigured(ctx, config, r.attributes, diagnostics)
+
+	if known && configured != 1 {
+		diagnostics.AddError(
+			"Invalid Attribute Combination",
+			fmt.Sprintf("Exactly one of %s must be configured.", strings.Join(r.attributes, ", ")),
+		)
+	}
+}
+
+// ValidateAtMostOneOf ensures no more than one of the given root
+// attributes is configured on a data source or resource.
+type ValidateAtMostOneOf struct {
+	attributes []string
+}
+
+func (r ValidateAtMostOneOf) Description(ctx context.Context) string {
+	return fmt.Sprintf("at most one of %s can be configured", strings.Join(r.attributes, ", "))
+}
+
+func (r ValidateAtMostOneOf) MarkdownDescription(ctx context.Context) string {
+	return r.Description(ctx)
+}
+
+func (r ValidateAtMostOneOf) ValidateDataSource(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	r.validate(ctx, req.Config, &resp.Diagnostics)
+}
+
+func (r ValidateAtMostOneOf) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	r.validate(ctx, req.Config, &resp.Diagnostics)
+}
+
+func (r ValidateAtMostOneOf) validate(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	configured, known := countConfigured(ctx, config, r.attributes, diagnostics)
+
+	if known && configured > 1 {
+		diagnostics.AddError(
+			"Invalid Attribute Combination",
+			fmt.Sprintf("At most one of %s can be configured.", strings.Join(r.attributes, ", ")),
+		)
+	}
+}
+
+// countConfigured returns how many of the root attributes are configured.
+// The count is not known while any of them is unknown.
+func countConfigured(ctx context.Context, config tfsdk.Config, attributes []string, diagnostics *diag.Diagnostics) (int, bool) {
+	var configured int
+
+	for _, attribute := range attributes {
+		var value attr.Value
+		diags := config.GetAttribute(ctx, path.Root(attribute), &value)
+
+		diagnostics.Append(diags...)
+		if diagnostics.HasError() {
+			return 0, false
+		}
+
+		if value.IsUnknown() {
+			// can't be checked until the value is known
+			return 0, false
+		}
+
+		if !value.IsNull() {
+			configured++
+		}
+	}
+
+	return configured, true
+}
+
+// ValidateCronSchedule ensures a schedule can be parsed by the cron library
+// used by Woodpecker.
+type ValidateCronSchedule struct{}
+
+func (r ValidateCronSchedule) Description(ctx context.Context) string {
+	return "value must be a valid cron schedule (e.g. \"0 0 2 * * *\" or \"@daily\")"
+}
+
+func (r ValidateCronSchedule) MarkdownDescription(ctx context.Context) string {
+	return r.Description(ctx)
+}
+
+func (r ValidateCronSchedule) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := cron.Parse(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Cron Schedule", err.Error())
+	}
+}